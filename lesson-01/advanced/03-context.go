@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/lannisite110/hello_world/pkg/ctxutil"
+)
+
+// contextDemo shows deadline and value propagation through a call chain.
+func contextDemo() {
+	fmt.Println("=== context ===")
+
+	ctx := ctxutil.WithRequestID(context.Background(), "demo-001")
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+
+	if err := databaseQueryDemo(ctx, 20*time.Millisecond); err != nil {
+		fmt.Printf("fast query failed: %v\n", err)
+	} else {
+		fmt.Println("fast query ok")
+	}
+	if err := databaseQueryDemo(ctx, 200*time.Millisecond); err != nil {
+		fmt.Printf("slow query failed as expected: %v\n", err)
+	}
+}
+
+// databaseQueryDemo simulates a database query that takes the given duration,
+// aborting early when the context expires.
+func databaseQueryDemo(ctx context.Context, queryTime time.Duration) error {
+	if id, ok := ctxutil.RequestID(ctx); ok {
+		fmt.Printf("  [req %s] querying...\n", id)
+	}
+	select {
+	case <-time.After(queryTime):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// fastQuery completes immediately; slowQuery grinds through a recursive CTE
+// long enough to trip any reasonable deadline.
+const (
+	fastQuery = `SELECT 1`
+	slowQuery = `WITH RECURSIVE cnt(x) AS (
+		SELECT 1 UNION ALL SELECT x+1 FROM cnt LIMIT 20000000
+	) SELECT count(*) FROM cnt`
+)
+
+// openDemoDB opens an in-memory SQLite database for the deadline demo.
+func openDemoDB() (*gorm.DB, error) {
+	return gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+}
+
+// runQuery executes query under ctx via WithContext, so a deadline on ctx
+// cancels the statement inside the driver.
+func runQuery(ctx context.Context, db *gorm.DB, query string) error {
+	var n int64
+	return db.WithContext(ctx).Raw(query).Scan(&n).Error
+}
+
+// gormQueryDemo demonstrates context.DeadlineExceeded surfacing from a real
+// database query instead of a simulated sleep.
+func gormQueryDemo() {
+	fmt.Println("=== gorm deadline ===")
+
+	db, err := openDemoDB()
+	if err != nil {
+		fmt.Printf("open db: %v\n", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := runQuery(ctx, db, fastQuery); err != nil {
+		fmt.Printf("fast query failed: %v\n", err)
+	} else {
+		fmt.Println("fast query ok")
+	}
+	if err := runQuery(ctx, db, slowQuery); err != nil {
+		fmt.Printf("slow query failed as expected: %v\n", err)
+	}
+}
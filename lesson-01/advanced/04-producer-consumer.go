@@ -0,0 +1,318 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// producerConsumerDemo shows the classic pattern: one producer feeding a
+// buffered channel, several consumers draining it, coordinated with
+// WaitGroups.
+func producerConsumerDemo() {
+	fmt.Println("=== producer/consumer ===")
+
+	jobs := make(chan int, 5)
+	results := make(chan int, 5)
+
+	var consumers sync.WaitGroup
+	for w := 1; w <= 3; w++ {
+		consumers.Add(1)
+		go func(id int) {
+			defer consumers.Done()
+			for job := range jobs {
+				results <- job * job
+			}
+		}(w)
+	}
+
+	go func() {
+		for i := 1; i <= 9; i++ {
+			jobs <- i
+		}
+		close(jobs)
+	}()
+
+	go func() {
+		consumers.Wait()
+		close(results)
+	}()
+
+	sum := 0
+	for r := range results {
+		sum += r
+	}
+	fmt.Printf("sum of squares 1..9 = %d\n", sum)
+}
+
+// PipelineStats reports what RunPipeline observed about queue pressure.
+type PipelineStats struct {
+	Processed       int // jobs completed by workers
+	BlockedProducer int // times the producer found the buffer full
+	MaxQueueDepth   int // highest observed job-queue fill
+}
+
+// pipelineWork is the job function RunPipeline applies; the inner loop makes
+// a worker measurably slower than the producer so backpressure is visible.
+func pipelineWork(job int) int {
+	sum := 0
+	for i := 0; i < 1000; i++ {
+		sum += job
+	}
+	return sum
+}
+
+// RunPipeline runs the producer/consumer pattern with a bounded job buffer
+// and reports backpressure stats alongside the results. The context stops
+// the producer early; jobs already queued still finish.
+func RunPipeline(ctx context.Context, numWorkers, bufferSize int, jobs []int) ([]int, PipelineStats) {
+	jobCh := make(chan int, bufferSize)
+	resultCh := make(chan int, len(jobs))
+
+	var stats PipelineStats
+	var processed atomic.Int64
+
+	var workers sync.WaitGroup
+	workers.Add(numWorkers)
+	for w := 0; w < numWorkers; w++ {
+		go func() {
+			defer workers.Done()
+			for job := range jobCh {
+				resultCh <- pipelineWork(job)
+				processed.Add(1)
+			}
+		}()
+	}
+
+produce:
+	for _, job := range jobs {
+		select {
+		case jobCh <- job:
+		default:
+			// Buffer full: count the backpressure event, then block.
+			stats.BlockedProducer++
+			select {
+			case jobCh <- job:
+			case <-ctx.Done():
+				break produce
+			}
+		}
+		if depth := len(jobCh); depth > stats.MaxQueueDepth {
+			stats.MaxQueueDepth = depth
+		}
+	}
+	close(jobCh)
+	workers.Wait()
+	close(resultCh)
+
+	results := make([]int, 0, len(jobs))
+	for r := range resultCh {
+		results = append(results, r)
+	}
+	stats.Processed = int(processed.Load())
+	return results, stats
+}
+
+// errGroupPipeline is the errgroup-based variant: each worker may fail, the
+// first error cancels the group via the derived context, and that error is
+// returned. Results arrive in completion order.
+func errGroupPipeline(ctx context.Context, numWorkers int, jobs []int, fn func(ctx context.Context, job int) (int, error)) ([]int, error) {
+	g, ctx := errgroup.WithContext(ctx)
+
+	jobCh := make(chan int)
+	g.Go(func() error {
+		defer close(jobCh)
+		for _, job := range jobs {
+			select {
+			case jobCh <- job:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	})
+
+	var mu sync.Mutex
+	var results []int
+	for w := 0; w < numWorkers; w++ {
+		g.Go(func() error {
+			for job := range jobCh {
+				r, err := fn(ctx, job)
+				if err != nil {
+					return err
+				}
+				mu.Lock()
+				results = append(results, r)
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// PoolResult is one processed job emitted by WorkerPool.
+type PoolResult struct {
+	Seq   int // submission order, starting at 0
+	Value int
+}
+
+// WorkerPool runs a fixed number of workers over a job channel. Results are
+// emitted in completion order, or in submission order when the pool is
+// created with ordered set.
+type WorkerPool struct {
+	jobs    chan poolJob
+	raw     chan PoolResult
+	results chan PoolResult
+	stop    chan struct{}
+	fn      func(int) int
+	wg      sync.WaitGroup
+	seq     int
+
+	mu      sync.Mutex
+	workers int
+}
+
+type poolJob struct {
+	seq   int
+	value int
+}
+
+// NewWorkerPool starts numWorkers goroutines applying fn to submitted jobs.
+// With ordered set, results are buffered and re-emitted in submission order
+// instead of completion order.
+func NewWorkerPool(numWorkers, jobBuffer int, ordered bool, fn func(int) int) *WorkerPool {
+	p := &WorkerPool{
+		jobs:    make(chan poolJob, jobBuffer),
+		raw:     make(chan PoolResult, jobBuffer),
+		results: make(chan PoolResult, jobBuffer),
+		stop:    make(chan struct{}),
+		fn:      fn,
+	}
+	p.spawn(numWorkers)
+	if ordered {
+		go p.reorder()
+	} else {
+		go func() {
+			for r := range p.raw {
+				p.results <- r
+			}
+			close(p.results)
+		}()
+	}
+	return p
+}
+
+// NewWorkerPoolAuto sizes the pool to the number of schedulable CPUs
+// (runtime.GOMAXPROCS), which suits CPU-bound job functions.
+func NewWorkerPoolAuto(jobBuffer int, ordered bool, fn func(int) int) *WorkerPool {
+	return NewWorkerPool(runtime.GOMAXPROCS(0), jobBuffer, ordered, fn)
+}
+
+// spawn starts n additional workers. Callers must hold no locks.
+func (p *WorkerPool) spawn(n int) {
+	p.mu.Lock()
+	p.workers += n
+	p.mu.Unlock()
+	p.wg.Add(n)
+	for w := 0; w < n; w++ {
+		go p.worker()
+	}
+}
+
+// worker processes jobs until the job channel closes or it receives a drain
+// signal; the signal is only checked between jobs, so the current job always
+// finishes.
+func (p *WorkerPool) worker() {
+	defer p.wg.Done()
+	defer func() {
+		p.mu.Lock()
+		p.workers--
+		p.mu.Unlock()
+	}()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case job, ok := <-p.jobs:
+			if !ok {
+				return
+			}
+			p.raw <- PoolResult{Seq: job.seq, Value: p.fn(job.value)}
+		}
+	}
+}
+
+// Workers returns the current number of live workers.
+func (p *WorkerPool) Workers() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.workers
+}
+
+// Resize grows or shrinks the pool to n workers. Shrinking signals the
+// excess workers to exit after the job they are currently running and blocks
+// until every signal is picked up, so it must not be called after Close.
+func (p *WorkerPool) Resize(n int) {
+	p.mu.Lock()
+	current := p.workers
+	p.mu.Unlock()
+
+	switch {
+	case n > current:
+		p.spawn(n - current)
+	case n < current:
+		for i := 0; i < current-n; i++ {
+			p.stop <- struct{}{}
+		}
+	}
+}
+
+// reorder holds back out-of-order results and emits them strictly by
+// sequence number.
+func (p *WorkerPool) reorder() {
+	pending := make(map[int]PoolResult)
+	next := 0
+	for r := range p.raw {
+		pending[r.Seq] = r
+		for {
+			buffered, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			p.results <- buffered
+			next++
+		}
+	}
+	close(p.results)
+}
+
+// Submit queues one job. Not safe for concurrent use with Close.
+func (p *WorkerPool) Submit(v int) {
+	p.jobs <- poolJob{seq: p.seq, value: v}
+	p.seq++
+}
+
+// Close signals no more jobs and closes the results channel once all workers
+// have drained.
+func (p *WorkerPool) Close() {
+	close(p.jobs)
+	go func() {
+		p.wg.Wait()
+		close(p.raw)
+	}()
+}
+
+// Results returns the channel of processed jobs.
+func (p *WorkerPool) Results() <-chan PoolResult {
+	return p.results
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunQueryDeadline(t *testing.T) {
+	db, err := openDemoDB()
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := runQuery(ctx, db, fastQuery); err != nil {
+		t.Fatalf("fast query: %v", err)
+	}
+
+	err = runQuery(ctx, db, slowQuery)
+	if err == nil {
+		t.Fatal("slow query succeeded, want deadline error")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("slow query error = %v, want context.DeadlineExceeded", err)
+	}
+}
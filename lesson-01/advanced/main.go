@@ -0,0 +1,17 @@
+// Lesson 01 (advanced): goroutine and channel patterns.
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+func main() {
+	contextDemo()
+	gormQueryDemo()
+	producerConsumerDemo()
+
+	results, err := errGroupPipeline(context.Background(), 3, []int{1, 2, 3, 4, 5},
+		func(ctx context.Context, job int) (int, error) { return job * 2, nil })
+	fmt.Printf("errgroup pipeline: %v (err=%v)\n", results, err)
+}
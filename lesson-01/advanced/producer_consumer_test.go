@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestErrGroupPipelineSuccess(t *testing.T) {
+	results, err := errGroupPipeline(context.Background(), 3, []int{1, 2, 3, 4},
+		func(ctx context.Context, job int) (int, error) { return job * job, nil })
+	if err != nil {
+		t.Fatalf("errGroupPipeline: %v", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("got %d results, want 4", len(results))
+	}
+	sum := 0
+	for _, r := range results {
+		sum += r
+	}
+	if sum != 1+4+9+16 {
+		t.Fatalf("sum = %d, want 30", sum)
+	}
+}
+
+func TestErrGroupPipelineFirstErrorCancels(t *testing.T) {
+	boom := errors.New("job 3 failed")
+
+	done := make(chan struct{})
+	var results []int
+	var err error
+	go func() {
+		defer close(done)
+		results, err = errGroupPipeline(context.Background(), 2, []int{1, 2, 3, 4, 5, 6, 7, 8},
+			func(ctx context.Context, job int) (int, error) {
+				if job == 3 {
+					return 0, boom
+				}
+				select {
+				case <-ctx.Done():
+					return 0, ctx.Err()
+				case <-time.After(5 * time.Millisecond):
+				}
+				return job, nil
+			})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("pipeline deadlocked")
+	}
+	if !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want %v", err, boom)
+	}
+	if results != nil {
+		t.Fatalf("results = %v, want nil on error", results)
+	}
+}
+
+func TestWorkerPoolOrderedResults(t *testing.T) {
+	// Earlier jobs sleep longer, so completion order is roughly reversed;
+	// the ordered pool must still emit in submission order.
+	pool := NewWorkerPool(4, 4, true, func(v int) int {
+		time.Sleep(time.Duration(10-v) * 5 * time.Millisecond)
+		return v * 10
+	})
+	const n = 8
+	for i := 0; i < n; i++ {
+		pool.Submit(i)
+	}
+	pool.Close()
+
+	var got []PoolResult
+	for r := range pool.Results() {
+		got = append(got, r)
+	}
+	if len(got) != n {
+		t.Fatalf("got %d results, want %d", len(got), n)
+	}
+	for i, r := range got {
+		if r.Seq != i {
+			t.Fatalf("result %d has seq %d, want %d (results out of order: %+v)", i, r.Seq, i, got)
+		}
+		if r.Value != i*10 {
+			t.Fatalf("result %d = %d, want %d", i, r.Value, i*10)
+		}
+	}
+}
+
+func TestWorkerPoolUnorderedDeliversAll(t *testing.T) {
+	pool := NewWorkerPool(3, 4, false, func(v int) int { return v + 1 })
+	for i := 0; i < 6; i++ {
+		pool.Submit(i)
+	}
+	pool.Close()
+
+	seen := make(map[int]bool)
+	for r := range pool.Results() {
+		seen[r.Value] = true
+	}
+	if len(seen) != 6 {
+		t.Fatalf("got %d distinct results, want 6", len(seen))
+	}
+}
+
+func waitForWorkers(t *testing.T, p *WorkerPool, want int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if p.Workers() == want {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("workers = %d, want %d", p.Workers(), want)
+}
+
+func TestWorkerPoolResize(t *testing.T) {
+	p := NewWorkerPool(2, 8, false, func(v int) int { return v * 2 })
+	waitForWorkers(t, p, 2)
+
+	// Drain results concurrently so workers never block on a full buffer
+	// while the pool is being resized.
+	seen := make(map[int]bool)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for r := range p.Results() {
+			seen[r.Value] = true
+		}
+	}()
+
+	for i := 0; i < 10; i++ {
+		p.Submit(i)
+	}
+
+	p.Resize(5)
+	waitForWorkers(t, p, 5)
+
+	for i := 10; i < 20; i++ {
+		p.Submit(i)
+	}
+
+	p.Resize(1)
+	waitForWorkers(t, p, 1)
+
+	for i := 20; i < 30; i++ {
+		p.Submit(i)
+	}
+	p.Close()
+	<-done
+	if len(seen) != 30 {
+		t.Fatalf("got %d distinct results, want 30", len(seen))
+	}
+	for i := 0; i < 30; i++ {
+		if !seen[i*2] {
+			t.Fatalf("missing result for job %d", i)
+		}
+	}
+}
+
+func TestNewWorkerPoolAuto(t *testing.T) {
+	p := NewWorkerPoolAuto(4, true, func(v int) int { return v + 1 })
+	if got := p.Workers(); got != runtime.GOMAXPROCS(0) {
+		t.Fatalf("workers = %d, want GOMAXPROCS = %d", got, runtime.GOMAXPROCS(0))
+	}
+	for i := 0; i < 5; i++ {
+		p.Submit(i)
+	}
+	p.Close()
+
+	var got []int
+	for r := range p.Results() {
+		got = append(got, r.Value)
+	}
+	for i, v := range got {
+		if v != i+1 {
+			t.Fatalf("results = %v, want ordered increments", got)
+		}
+	}
+}
+
+func TestRunPipelineBackpressure(t *testing.T) {
+	jobs := make([]int, 200)
+	for i := range jobs {
+		jobs[i] = i
+	}
+
+	results, stats := RunPipeline(context.Background(), 1, 1, jobs)
+	if len(results) != len(jobs) {
+		t.Fatalf("got %d results, want %d", len(results), len(jobs))
+	}
+	if stats.Processed != len(jobs) {
+		t.Fatalf("processed = %d, want %d", stats.Processed, len(jobs))
+	}
+	if stats.BlockedProducer == 0 {
+		t.Fatal("no backpressure events counted with a single slow worker and buffer 1")
+	}
+	if stats.MaxQueueDepth < 1 {
+		t.Fatalf("max queue depth = %d, want >= 1", stats.MaxQueueDepth)
+	}
+
+	sum := 0
+	for _, r := range results {
+		sum += r
+	}
+	want := 0
+	for _, j := range jobs {
+		want += j * 1000
+	}
+	if sum != want {
+		t.Fatalf("result sum = %d, want %d", sum, want)
+	}
+}
+
+func TestRunPipelineCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	jobs := make([]int, 500)
+	for i := range jobs {
+		jobs[i] = 1
+	}
+	results, stats := RunPipeline(ctx, 1, 1, jobs)
+	if len(results) == len(jobs) && stats.BlockedProducer > 0 {
+		t.Fatal("cancelled producer still delivered every job past a full buffer")
+	}
+	if stats.Processed != len(results) {
+		t.Fatalf("processed = %d but %d results", stats.Processed, len(results))
+	}
+}
@@ -0,0 +1,149 @@
+// Lesson 01 (basics): structs, methods and interfaces.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Person demonstrates struct tags; the validate tags are enforced by
+// Validate below.
+type Person struct {
+	Name string `validate:"required,nonempty"`
+	Age  int    `validate:"min=0,max=150"`
+	City string
+}
+
+// introduce implements Speaker.
+func (p Person) introduce() string {
+	return fmt.Sprintf("Hi, I'm %s, %d years old", p.Name, p.Age)
+}
+
+// Speaker is anything that can introduce itself.
+type Speaker interface {
+	introduce() string
+}
+
+// Robot is a second Speaker implementation for the dispatch demo.
+type Robot struct {
+	Model string
+}
+
+// introduce implements Speaker.
+func (r Robot) introduce() string {
+	return fmt.Sprintf("BEEP. I am unit %s", r.Model)
+}
+
+// SpeakerRegistry holds named Speaker implementations so a whole group can
+// be dispatched polymorphically.
+type SpeakerRegistry struct {
+	speakers map[string]Speaker
+}
+
+// NewSpeakerRegistry returns an empty registry.
+func NewSpeakerRegistry() *SpeakerRegistry {
+	return &SpeakerRegistry{speakers: make(map[string]Speaker)}
+}
+
+// Register stores s under name, replacing any previous entry.
+func (sr *SpeakerRegistry) Register(name string, s Speaker) {
+	sr.speakers[name] = s
+}
+
+// SpeakAll invokes every registered speaker, in name order so the output is
+// deterministic.
+func (sr *SpeakerRegistry) SpeakAll() []string {
+	names := make([]string, 0, len(sr.speakers))
+	for name := range sr.speakers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		out = append(out, sr.speakers[name].introduce())
+	}
+	return out
+}
+
+// Validate checks the `validate:"..."` tags of a struct (or pointer to one)
+// and returns all violations joined into a single error, or nil. Supported
+// rules: required (non-zero value), nonempty (strings), min=N and max=N
+// (ints).
+func Validate(v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("validate: want struct, got %T", v)
+	}
+
+	var violations []error
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag := field.Tag.Get("validate")
+		if tag == "" {
+			continue
+		}
+		value := rv.Field(i)
+		for _, rule := range strings.Split(tag, ",") {
+			if err := checkRule(field.Name, value, rule); err != nil {
+				violations = append(violations, err)
+			}
+		}
+	}
+	return errors.Join(violations...)
+}
+
+func checkRule(name string, value reflect.Value, rule string) error {
+	switch {
+	case rule == "required":
+		if value.IsZero() {
+			return fmt.Errorf("%s is required", name)
+		}
+	case rule == "nonempty":
+		if value.Kind() == reflect.String && value.String() == "" {
+			return fmt.Errorf("%s must not be empty", name)
+		}
+	case strings.HasPrefix(rule, "min="):
+		bound, err := strconv.ParseInt(rule[len("min="):], 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s: bad rule %q", name, rule)
+		}
+		if value.CanInt() && value.Int() < bound {
+			return fmt.Errorf("%s must be at least %d", name, bound)
+		}
+	case strings.HasPrefix(rule, "max="):
+		bound, err := strconv.ParseInt(rule[len("max="):], 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s: bad rule %q", name, rule)
+		}
+		if value.CanInt() && value.Int() > bound {
+			return fmt.Errorf("%s must be at most %d", name, bound)
+		}
+	default:
+		return fmt.Errorf("%s: unknown rule %q", name, rule)
+	}
+	return nil
+}
+
+func structDemo() {
+	alice := Person{Name: "Alice", Age: 30, City: "Berlin"}
+	fmt.Println(alice.introduce())
+
+	bad := Person{Age: 200}
+	fmt.Printf("validate: %v\n", Validate(bad))
+
+	registry := NewSpeakerRegistry()
+	registry.Register("alice", alice)
+	registry.Register("r2", Robot{Model: "R2"})
+	for _, line := range registry.SpeakAll() {
+		fmt.Println(line)
+	}
+}
@@ -0,0 +1,197 @@
+// Lesson 01 (basics): a calculator with operation history.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+)
+
+// ErrDivideByZero is returned by Calculate for a zero divisor.
+var ErrDivideByZero = errors.New("divide by zero")
+
+// HistoryEntry records one performed operation.
+type HistoryEntry struct {
+	Op       string    `json:"op"`
+	Operands []float64 `json:"operands"`
+	Result   float64   `json:"result"`
+}
+
+// Calculator performs arithmetic and remembers everything it did.
+type Calculator struct {
+	history []HistoryEntry
+}
+
+// NewCalculator returns a calculator with empty history.
+func NewCalculator() *Calculator {
+	return &Calculator{}
+}
+
+func (c *Calculator) record(op string, operands []float64, result float64) {
+	c.history = append(c.history, HistoryEntry{Op: op, Operands: operands, Result: result})
+}
+
+// Calculate applies a binary operator ("+", "-", "*", "/") to a and b.
+func (c *Calculator) Calculate(op string, a, b float64) (float64, error) {
+	var result float64
+	switch op {
+	case "+":
+		result = a + b
+	case "-":
+		result = a - b
+	case "*":
+		result = a * b
+	case "/":
+		if b == 0 {
+			return 0, ErrDivideByZero
+		}
+		result = a / b
+	default:
+		return 0, fmt.Errorf("unknown operator %q", op)
+	}
+	c.record(op, []float64{a, b}, result)
+	return result, nil
+}
+
+// Sum adds all numbers.
+func (c *Calculator) Sum(numbers ...float64) float64 {
+	var total float64
+	for _, n := range numbers {
+		total += n
+	}
+	c.record("sum", numbers, total)
+	return total
+}
+
+// Average returns the arithmetic mean, erroring on empty input.
+func (c *Calculator) Average(numbers ...float64) (float64, error) {
+	if len(numbers) == 0 {
+		return 0, errors.New("average of no numbers")
+	}
+	var total float64
+	for _, n := range numbers {
+		total += n
+	}
+	avg := total / float64(len(numbers))
+	c.record("average", numbers, avg)
+	return avg, nil
+}
+
+// ErrNoNumbers is returned by the aggregate methods for empty input.
+var ErrNoNumbers = errors.New("no numbers given")
+
+// Min returns the smallest number.
+func (c *Calculator) Min(numbers ...float64) (float64, error) {
+	if len(numbers) == 0 {
+		return 0, ErrNoNumbers
+	}
+	min := numbers[0]
+	for _, n := range numbers[1:] {
+		if n < min {
+			min = n
+		}
+	}
+	c.record("min", numbers, min)
+	return min, nil
+}
+
+// Max returns the largest number.
+func (c *Calculator) Max(numbers ...float64) (float64, error) {
+	if len(numbers) == 0 {
+		return 0, ErrNoNumbers
+	}
+	max := numbers[0]
+	for _, n := range numbers[1:] {
+		if n > max {
+			max = n
+		}
+	}
+	c.record("max", numbers, max)
+	return max, nil
+}
+
+// Median returns the middle value; for even-length input it averages the two
+// middle values.
+func (c *Calculator) Median(numbers ...float64) (float64, error) {
+	if len(numbers) == 0 {
+		return 0, ErrNoNumbers
+	}
+	sorted := make([]float64, len(numbers))
+	copy(sorted, numbers)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	var median float64
+	if len(sorted)%2 == 0 {
+		median = (sorted[mid-1] + sorted[mid]) / 2
+	} else {
+		median = sorted[mid]
+	}
+	c.record("median", numbers, median)
+	return median, nil
+}
+
+// StdDev returns the population standard deviation.
+func (c *Calculator) StdDev(numbers ...float64) (float64, error) {
+	if len(numbers) == 0 {
+		return 0, ErrNoNumbers
+	}
+	var total float64
+	for _, n := range numbers {
+		total += n
+	}
+	mean := total / float64(len(numbers))
+
+	var sumSq float64
+	for _, n := range numbers {
+		sumSq += (n - mean) * (n - mean)
+	}
+	stddev := math.Sqrt(sumSq / float64(len(numbers)))
+	c.record("stddev", numbers, stddev)
+	return stddev, nil
+}
+
+// History returns a copy of the recorded operations.
+func (c *Calculator) History() []HistoryEntry {
+	out := make([]HistoryEntry, len(c.history))
+	copy(out, c.history)
+	return out
+}
+
+// SaveHistory serializes the history to a JSON file so a session can be
+// resumed later with LoadHistory.
+func (c *Calculator) SaveHistory(path string) error {
+	data, err := json.MarshalIndent(c.history, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode history: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("write history: %w", err)
+	}
+	return nil
+}
+
+// LoadHistory reads a JSON history file, replacing the current history.
+func (c *Calculator) LoadHistory(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read history: %w", err)
+	}
+	var loaded []HistoryEntry
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return fmt.Errorf("decode history: %w", err)
+	}
+	c.history = loaded
+	return nil
+}
+
+func calculatorDemo() {
+	calc := NewCalculator()
+	sum, _ := calc.Calculate("+", 2, 3)
+	quot, _ := calc.Calculate("/", 10, 4)
+	fmt.Printf("2+3=%v 10/4=%v sum(1..4)=%v\n", sum, quot, calc.Sum(1, 2, 3, 4))
+	fmt.Printf("history: %d entries\n", len(calc.History()))
+}
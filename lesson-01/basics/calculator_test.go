@@ -0,0 +1,115 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCalculate(t *testing.T) {
+	calc := NewCalculator()
+
+	got, err := calc.Calculate("+", 2, 3)
+	if err != nil || got != 5 {
+		t.Fatalf("2+3 = %v, %v", got, err)
+	}
+	if _, err := calc.Calculate("/", 1, 0); !errors.Is(err, ErrDivideByZero) {
+		t.Fatalf("1/0 err = %v, want ErrDivideByZero", err)
+	}
+	if _, err := calc.Calculate("%", 1, 2); err == nil {
+		t.Fatal("unknown operator accepted")
+	}
+	if n := len(calc.History()); n != 1 {
+		t.Fatalf("history has %d entries, want 1 (failed ops not recorded)", n)
+	}
+}
+
+func TestSaveAndLoadHistory(t *testing.T) {
+	calc := NewCalculator()
+	if _, err := calc.Calculate("*", 6, 7); err != nil {
+		t.Fatalf("calculate: %v", err)
+	}
+	calc.Sum(1, 2, 3)
+	if _, err := calc.Average(2, 4); err != nil {
+		t.Fatalf("average: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "history.json")
+	if err := calc.SaveHistory(path); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	fresh := NewCalculator()
+	fresh.Sum(9) // should be replaced by the load
+	if err := fresh.LoadHistory(path); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	if !reflect.DeepEqual(fresh.History(), calc.History()) {
+		t.Fatalf("loaded history %+v\nwant %+v", fresh.History(), calc.History())
+	}
+}
+
+func TestLoadHistoryMissingFile(t *testing.T) {
+	calc := NewCalculator()
+	if err := calc.LoadHistory(filepath.Join(t.TempDir(), "absent.json")); err == nil {
+		t.Fatal("expected error for missing file")
+	}
+}
+
+func TestStatistics(t *testing.T) {
+	type statFn func(c *Calculator, numbers ...float64) (float64, error)
+
+	stats := map[string]statFn{
+		"min":    (*Calculator).Min,
+		"max":    (*Calculator).Max,
+		"median": (*Calculator).Median,
+		"stddev": (*Calculator).StdDev,
+	}
+
+	tests := []struct {
+		name    string
+		stat    string
+		input   []float64
+		want    float64
+		wantErr error
+	}{
+		{"min single", "min", []float64{4}, 4, nil},
+		{"min many", "min", []float64{3, -1, 7}, -1, nil},
+		{"min empty", "min", nil, 0, ErrNoNumbers},
+		{"max single", "max", []float64{4}, 4, nil},
+		{"max many", "max", []float64{3, -1, 7}, 7, nil},
+		{"max empty", "max", nil, 0, ErrNoNumbers},
+		{"median single", "median", []float64{4}, 4, nil},
+		{"median odd", "median", []float64{9, 1, 5}, 5, nil},
+		{"median even", "median", []float64{1, 2, 3, 10}, 2.5, nil},
+		{"median empty", "median", nil, 0, ErrNoNumbers},
+		{"stddev single", "stddev", []float64{4}, 0, nil},
+		{"stddev even", "stddev", []float64{2, 4, 4, 4, 5, 5, 7, 9}, 2, nil},
+		{"stddev empty", "stddev", nil, 0, ErrNoNumbers},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calc := NewCalculator()
+			got, err := stats[tt.stat](calc, tt.input...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if n := len(calc.History()); n != 0 {
+					t.Fatalf("failed op recorded %d history entries", n)
+				}
+				return
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Fatalf("%s(%v) = %v, want %v", tt.stat, tt.input, got, tt.want)
+			}
+			if n := len(calc.History()); n != 1 {
+				t.Fatalf("history has %d entries, want 1", n)
+			}
+		})
+	}
+}
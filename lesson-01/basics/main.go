@@ -0,0 +1,7 @@
+// Lesson 01 (basics): language fundamentals.
+package main
+
+func main() {
+	calculatorDemo()
+	structDemo()
+}
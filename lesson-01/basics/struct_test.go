@@ -0,0 +1,83 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		person  Person
+		wantErr string // substring of the error, empty means valid
+	}{
+		{"valid", Person{Name: "Alice", Age: 30}, ""},
+		{"empty name", Person{Name: "", Age: 30}, "Name must not be empty"},
+		{"age below min", Person{Name: "Bob", Age: -1}, "Age must be at least 0"},
+		{"age above max", Person{Name: "Bob", Age: 200}, "Age must be at most 150"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.person)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCollectsAllViolations(t *testing.T) {
+	err := Validate(Person{Name: "", Age: -5})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, want := range []string{"Name is required", "Name must not be empty", "Age must be at least 0"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
+
+func TestValidateNonStruct(t *testing.T) {
+	if err := Validate(42); err == nil {
+		t.Fatal("expected error for non-struct")
+	}
+}
+
+func TestSpeakerRegistry(t *testing.T) {
+	registry := NewSpeakerRegistry()
+	registry.Register("bob", Person{Name: "Bob", Age: 25})
+	registry.Register("android", Robot{Model: "X1"})
+
+	got := registry.SpeakAll()
+	want := []string{
+		"BEEP. I am unit X1",
+		"Hi, I'm Bob, 25 years old",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("SpeakAll = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("SpeakAll[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSpeakerRegistryReplace(t *testing.T) {
+	registry := NewSpeakerRegistry()
+	registry.Register("x", Person{Name: "Old", Age: 1})
+	registry.Register("x", Person{Name: "New", Age: 2})
+
+	got := registry.SpeakAll()
+	if len(got) != 1 || !strings.Contains(got[0], "New") {
+		t.Fatalf("SpeakAll = %v, want single entry for New", got)
+	}
+}
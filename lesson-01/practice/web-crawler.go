@@ -0,0 +1,306 @@
+// Lesson 01 (practice): a small concurrent web crawler.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// PageContent is the outcome of fetching one URL.
+type PageContent struct {
+	URL    string
+	Status int
+	Body   string
+	Err    error
+}
+
+// RetryPolicy controls how fetchURL retries transient failures.
+type RetryPolicy struct {
+	MaxAttempts int           // total attempts, including the first
+	BaseDelay   time.Duration // delay before the second attempt
+	MaxDelay    time.Duration // cap for the exponential backoff
+}
+
+// DefaultRetryPolicy retries twice with a 200ms/400ms backoff.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    2 * time.Second,
+}
+
+// webCrawler fetches a set of URLs with bounded concurrency. Besides the
+// global cap, perHostLimit bounds how many requests may hit one host at a
+// time (0 means no per-host limit), so a batch heavy on a single site does
+// not hammer it.
+type webCrawler struct {
+	maxConcurrency int
+	perHostLimit   int
+	timeout        time.Duration
+	retry          RetryPolicy
+	transport      http.RoundTripper // nil means a pooled clone of http.DefaultTransport
+
+	clientOnce sync.Once
+	client     *http.Client
+
+	hostMu   sync.Mutex
+	hostSems map[string]chan struct{}
+}
+
+func newWebCrawler(maxConcurrency int, timeout time.Duration) *webCrawler {
+	return &webCrawler{
+		maxConcurrency: maxConcurrency,
+		timeout:        timeout,
+		retry:          DefaultRetryPolicy,
+		hostSems:       make(map[string]chan struct{}),
+	}
+}
+
+// httpClient returns the crawler's shared HTTP client, built on first use so
+// every fetch reuses one connection pool instead of dialing fresh each time.
+func (wc *webCrawler) httpClient() *http.Client {
+	wc.clientOnce.Do(func() {
+		transport := wc.transport
+		if transport == nil {
+			t := http.DefaultTransport.(*http.Transport).Clone()
+			t.MaxIdleConnsPerHost = wc.maxConcurrency
+			transport = t
+		}
+		wc.client = &http.Client{Timeout: wc.timeout, Transport: transport}
+	})
+	return wc.client
+}
+
+// hostSem returns the semaphore for host, creating it on first use.
+func (wc *webCrawler) hostSem(host string) chan struct{} {
+	wc.hostMu.Lock()
+	defer wc.hostMu.Unlock()
+	sem, ok := wc.hostSems[host]
+	if !ok {
+		sem = make(chan struct{}, wc.perHostLimit)
+		wc.hostSems[host] = sem
+	}
+	return sem
+}
+
+// backoff returns how long to wait before attempt n (0-based), doubling the
+// base delay each time up to MaxDelay.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay << uint(attempt)
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d
+}
+
+// retryable reports whether a fetch outcome is worth another attempt:
+// network-level errors and 5xx responses are, 4xx responses are not.
+func retryable(status int, err error) bool {
+	if status >= 400 && status < 500 {
+		return false
+	}
+	return err != nil || status >= 500
+}
+
+// fetchURL downloads one page, retrying transient failures with exponential
+// backoff according to the crawler's RetryPolicy. Cancelling ctx aborts the
+// in-flight request and stops further retries.
+func (wc *webCrawler) fetchURL(ctx context.Context, url string) *PageContent {
+	client := wc.httpClient()
+	result := &PageContent{URL: url}
+
+	attempts := wc.retry.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				result.Err = ctx.Err()
+				return result
+			case <-time.After(wc.retry.backoff(attempt - 1)):
+			}
+		}
+
+		status, body, err := doFetch(ctx, client, url)
+		result.Status, result.Body, result.Err = status, body, err
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			break
+		}
+		if !retryable(status, err) {
+			break
+		}
+	}
+	return result
+}
+
+func doFetch(ctx context.Context, client *http.Client, url string) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, "", err
+	}
+	if resp.StatusCode >= 400 {
+		return resp.StatusCode, "", fmt.Errorf("unexpected status %d for %s", resp.StatusCode, url)
+	}
+	return resp.StatusCode, string(body), nil
+}
+
+// CrawURLS fetches all URLs with at most maxConcurrency in flight, returning
+// results in input order.
+func (wc *webCrawler) CrawURLS(urls []string) []*PageContent {
+	return wc.CrawURLSContext(context.Background(), urls)
+}
+
+// CrawURLSContext is CrawURLS with cancellation: cancelling ctx aborts the
+// in-flight fetches, whose results then carry the context error.
+func (wc *webCrawler) CrawURLSContext(ctx context.Context, urls []string) []*PageContent {
+	results := make([]*PageContent, len(urls))
+	sem := make(chan struct{}, wc.maxConcurrency)
+
+	var wg sync.WaitGroup
+	for i, u := range urls {
+		wg.Add(1)
+		go func(i int, rawURL string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if wc.perHostLimit > 0 {
+				if u, err := url.Parse(rawURL); err == nil {
+					hostSem := wc.hostSem(u.Host)
+					hostSem <- struct{}{}
+					defer func() { <-hostSem }()
+				}
+			}
+			results[i] = wc.fetchURL(ctx, rawURL)
+		}(i, u)
+	}
+	wg.Wait()
+	return results
+}
+
+// CrawlSummary aggregates one crawl run.
+type CrawlSummary struct {
+	TotalURLs    int            // URLs attempted
+	Successes    int            // fetches without error
+	Errors       int            // fetches that failed
+	TotalBytes   int            // bytes across successful bodies
+	AvgBodySize  int            // TotalBytes / Successes (0 when nothing succeeded)
+	ErrorsByType map[string]int // failure counts keyed by classifyError
+}
+
+// classifyError buckets a fetch error for the summary breakdown.
+func classifyError(page *PageContent) string {
+	err := page.Err
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "cancelled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case page.Status >= 500:
+		return "server_error"
+	case page.Status >= 400:
+		return "client_error"
+	default:
+		return "network"
+	}
+}
+
+// Summarize reduces per-URL results to one CrawlSummary.
+func Summarize(contents []*PageContent) CrawlSummary {
+	summary := CrawlSummary{
+		TotalURLs:    len(contents),
+		ErrorsByType: make(map[string]int),
+	}
+	for _, page := range contents {
+		if page.Err != nil {
+			summary.Errors++
+			summary.ErrorsByType[classifyError(page)]++
+			continue
+		}
+		summary.Successes++
+		summary.TotalBytes += len(page.Body)
+	}
+	if summary.Successes > 0 {
+		summary.AvgBodySize = summary.TotalBytes / summary.Successes
+	}
+	return summary
+}
+
+// resultLine is the JSONL shape of one crawl result; the error is rendered
+// as a plain string since error values do not marshal usefully.
+type resultLine struct {
+	URL      string `json:"url"`
+	Status   int    `json:"status"`
+	BodyLen  int    `json:"body_len"`
+	ErrorMsg string `json:"error,omitempty"`
+}
+
+// WriteResultsJSONL writes one JSON object per line for each result, so a
+// crawl can be piped to a file and processed incrementally.
+func WriteResultsJSONL(w io.Writer, contents []*PageContent) error {
+	enc := json.NewEncoder(w)
+	for _, page := range contents {
+		line := resultLine{
+			URL:     page.URL,
+			Status:  page.Status,
+			BodyLen: len(page.Body),
+		}
+		if page.Err != nil {
+			line.ErrorMsg = page.Err.Error()
+		}
+		if err := enc.Encode(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func main() {
+	urls := []string{
+		"https://example.com",
+		"https://example.org",
+		"https://example.net",
+	}
+
+	crawler := newWebCrawler(3, 5*time.Second)
+	start := time.Now()
+	results := crawler.CrawURLS(urls)
+
+	for _, page := range results {
+		if page.Err != nil {
+			fmt.Printf("FAIL %s: %v\n", page.URL, page.Err)
+			continue
+		}
+		fmt.Printf("OK   %s: %d bytes (status %d)\n", page.URL, len(page.Body), page.Status)
+	}
+	summary := Summarize(results)
+	fmt.Printf("crawled %d/%d pages (%d bytes, avg %d) in %s\n",
+		summary.Successes, summary.TotalURLs, summary.TotalBytes, summary.AvgBodySize,
+		time.Since(start).Round(time.Millisecond))
+	for kind, n := range summary.ErrorsByType {
+		fmt.Printf("  %s errors: %d\n", kind, n)
+	}
+
+	if err := WriteResultsJSONL(os.Stdout, results); err != nil {
+		fmt.Fprintf(os.Stderr, "write results: %v\n", err)
+	}
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testCrawler() *webCrawler {
+	wc := newWebCrawler(4, time.Second)
+	wc.retry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+	return wc
+}
+
+func TestFetchURLRetriesTransientErrors(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("finally"))
+	}))
+	defer srv.Close()
+
+	page := testCrawler().fetchURL(context.Background(), srv.URL)
+	if page.Err != nil {
+		t.Fatalf("fetch: %v", page.Err)
+	}
+	if page.Body != "finally" {
+		t.Fatalf("body = %q, want %q", page.Body, "finally")
+	}
+	if got := hits.Load(); got != 3 {
+		t.Fatalf("server hit %d times, want 3", got)
+	}
+}
+
+func TestFetchURLDoesNotRetry4xx(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	page := testCrawler().fetchURL(context.Background(), srv.URL)
+	if page.Err == nil {
+		t.Fatal("expected error for 404")
+	}
+	if got := hits.Load(); got != 1 {
+		t.Fatalf("server hit %d times, want 1", got)
+	}
+}
+
+func TestFetchURLGivesUpAfterMaxAttempts(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	page := testCrawler().fetchURL(context.Background(), srv.URL)
+	if page.Err == nil {
+		t.Fatal("expected error after exhausting retries")
+	}
+	if got := hits.Load(); got != 3 {
+		t.Fatalf("server hit %d times, want 3", got)
+	}
+}
+
+// countingTransport tracks in-flight requests per host and overall.
+type countingTransport struct {
+	mu         sync.Mutex
+	inflight   map[string]int
+	maxPerHost map[string]int
+	total      int
+	maxTotal   int
+}
+
+func newCountingTransport() *countingTransport {
+	return &countingTransport{
+		inflight:   make(map[string]int),
+		maxPerHost: make(map[string]int),
+	}
+}
+
+func (ct *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	ct.mu.Lock()
+	ct.inflight[host]++
+	ct.total++
+	if ct.inflight[host] > ct.maxPerHost[host] {
+		ct.maxPerHost[host] = ct.inflight[host]
+	}
+	if ct.total > ct.maxTotal {
+		ct.maxTotal = ct.total
+	}
+	ct.mu.Unlock()
+
+	resp, err := http.DefaultTransport.RoundTrip(req)
+
+	ct.mu.Lock()
+	ct.inflight[host]--
+	ct.total--
+	ct.mu.Unlock()
+	return resp, err
+}
+
+func TestCrawURLSPerHostLimit(t *testing.T) {
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(30 * time.Millisecond)
+		w.Write([]byte("ok"))
+	})
+	srvA := httptest.NewServer(slow)
+	defer srvA.Close()
+	srvB := httptest.NewServer(slow)
+	defer srvB.Close()
+
+	ct := newCountingTransport()
+	wc := newWebCrawler(4, time.Second)
+	wc.perHostLimit = 1
+	wc.transport = ct
+
+	urls := []string{srvA.URL, srvA.URL, srvA.URL, srvB.URL, srvB.URL, srvB.URL}
+	results := wc.CrawURLS(urls)
+	for _, page := range results {
+		if page.Err != nil {
+			t.Fatalf("fetch %s: %v", page.URL, page.Err)
+		}
+	}
+
+	for host, max := range ct.maxPerHost {
+		if max > 1 {
+			t.Errorf("host %s saw %d concurrent requests, want at most 1", host, max)
+		}
+	}
+	if ct.maxTotal < 2 {
+		t.Errorf("total concurrency peaked at %d, want at least 2 (both hosts in parallel)", ct.maxTotal)
+	}
+}
+
+func TestWriteResultsJSONL(t *testing.T) {
+	results := []*PageContent{
+		{URL: "https://a.example", Status: 200, Body: "hello"},
+		{URL: "https://b.example", Status: 503, Err: errors.New("unexpected status 503")},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteResultsJSONL(&buf, results); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), buf.String())
+	}
+
+	var first, second resultLine
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("parse line 1: %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatalf("parse line 2: %v", err)
+	}
+
+	if first.URL != "https://a.example" || first.Status != 200 || first.BodyLen != 5 || first.ErrorMsg != "" {
+		t.Errorf("line 1 = %+v", first)
+	}
+	if second.URL != "https://b.example" || second.Status != 503 || second.ErrorMsg != "unexpected status 503" {
+		t.Errorf("line 2 = %+v", second)
+	}
+}
+
+func TestCrawURLSContextCancelAbortsInFlight(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release // hold every request until the test lets go
+		w.Write([]byte("late"))
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	wc := newWebCrawler(4, 10*time.Second)
+	wc.retry = RetryPolicy{MaxAttempts: 1}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	results := wc.CrawURLSContext(ctx, []string{srv.URL, srv.URL, srv.URL})
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("cancellation took %s, want prompt abort", elapsed)
+	}
+
+	for _, page := range results {
+		if !errors.Is(page.Err, context.Canceled) {
+			t.Fatalf("fetch %s: err = %v, want context.Canceled", page.URL, page.Err)
+		}
+	}
+}
+
+func TestCrawlerReusesOneClient(t *testing.T) {
+	wc := newWebCrawler(2, time.Second)
+	if wc.httpClient() != wc.httpClient() {
+		t.Fatal("httpClient must return the same shared client")
+	}
+}
+
+func TestSummarizeMixedResults(t *testing.T) {
+	contents := []*PageContent{
+		{URL: "a", Status: 200, Body: strings.Repeat("x", 100)},
+		{URL: "b", Status: 200, Body: strings.Repeat("x", 300)},
+		{URL: "c", Status: 503, Err: errors.New("unexpected status 503 for c")},
+		{URL: "d", Status: 404, Err: errors.New("unexpected status 404 for d")},
+		{URL: "e", Err: context.Canceled},
+		{URL: "f", Err: errors.New("dial tcp: connection refused")},
+	}
+
+	summary := Summarize(contents)
+	if summary.TotalURLs != 6 {
+		t.Fatalf("TotalURLs = %d, want 6", summary.TotalURLs)
+	}
+	if summary.Successes != 2 || summary.Errors != 4 {
+		t.Fatalf("successes/errors = %d/%d, want 2/4", summary.Successes, summary.Errors)
+	}
+	if summary.TotalBytes != 400 || summary.AvgBodySize != 200 {
+		t.Fatalf("bytes/avg = %d/%d, want 400/200", summary.TotalBytes, summary.AvgBodySize)
+	}
+
+	want := map[string]int{"server_error": 1, "client_error": 1, "cancelled": 1, "network": 1}
+	for kind, n := range want {
+		if summary.ErrorsByType[kind] != n {
+			t.Fatalf("ErrorsByType[%s] = %d, want %d (all: %v)", kind, summary.ErrorsByType[kind], n, summary.ErrorsByType)
+		}
+	}
+}
+
+func TestSummarizeAllFailed(t *testing.T) {
+	summary := Summarize([]*PageContent{{URL: "a", Err: errors.New("boom")}})
+	if summary.Successes != 0 || summary.AvgBodySize != 0 {
+		t.Fatalf("no successes: %+v", summary)
+	}
+}
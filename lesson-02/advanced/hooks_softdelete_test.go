@@ -0,0 +1,113 @@
+// Lesson 02 (advanced): GORM hooks, audit columns and soft delete.
+package advanced
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/lannisite110/hello_world/lesson-02/gormx"
+	"github.com/lannisite110/hello_world/lesson-02/testutil"
+	"github.com/lannisite110/hello_world/pkg/ctxutil"
+)
+
+// article demonstrates soft delete plus the shared audit mixin.
+type article struct {
+	ID      uint   `gorm:"primarykey"`
+	Title   string `gorm:"size:128"`
+	Content string
+	gormx.AuditFields
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// author demonstrates that a second model type gets the same audit behavior
+// just by embedding the mixin.
+type author struct {
+	ID   uint   `gorm:"primarykey"`
+	Name string `gorm:"size:64"`
+	gormx.AuditFields
+}
+
+func TestAuditFieldsFromContext(t *testing.T) {
+	db := testutil.NewTestDB(t, &article{}, &author{})
+
+	ctx := ctxutil.WithOperator(context.Background(), "alice")
+
+	a := &article{Title: "hello", Content: "first post"}
+	if err := db.WithContext(ctx).Create(a).Error; err != nil {
+		t.Fatalf("create article: %v", err)
+	}
+	b := &author{Name: "bob"}
+	if err := db.WithContext(ctx).Create(b).Error; err != nil {
+		t.Fatalf("create author: %v", err)
+	}
+
+	var gotArticle article
+	if err := db.First(&gotArticle, a.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if gotArticle.CreatedBy != "alice" || gotArticle.UpdatedBy != "alice" {
+		t.Fatalf("article audit = %q/%q, want alice/alice", gotArticle.CreatedBy, gotArticle.UpdatedBy)
+	}
+	var gotAuthor author
+	if err := db.First(&gotAuthor, b.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if gotAuthor.CreatedBy != "alice" {
+		t.Fatalf("author CreatedBy = %q, want alice", gotAuthor.CreatedBy)
+	}
+
+	// A different operator updates; only UpdatedBy must change.
+	updateCtx := ctxutil.WithOperator(context.Background(), "carol")
+	if err := db.WithContext(updateCtx).Model(&gotArticle).Update("title", "hello v2").Error; err != nil {
+		t.Fatalf("update article: %v", err)
+	}
+	if err := db.First(&gotArticle, a.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if gotArticle.CreatedBy != "alice" || gotArticle.UpdatedBy != "carol" {
+		t.Fatalf("article audit after update = %q/%q, want alice/carol", gotArticle.CreatedBy, gotArticle.UpdatedBy)
+	}
+}
+
+func TestAuditFieldsWithoutOperator(t *testing.T) {
+	db := testutil.NewTestDB(t, &article{})
+
+	a := &article{Title: "anonymous"}
+	if err := db.Create(a).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	var got article
+	if err := db.First(&got, a.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if got.CreatedBy != "" || got.UpdatedBy != "" {
+		t.Fatalf("audit fields set without operator: %q/%q", got.CreatedBy, got.UpdatedBy)
+	}
+}
+
+func TestSoftDelete(t *testing.T) {
+	db := testutil.NewTestDB(t, &article{})
+
+	a := &article{Title: "to delete"}
+	if err := db.Create(a).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := db.Delete(a).Error; err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	var got article
+	err := db.First(&got, a.ID).Error
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("find after soft delete = %v, want ErrRecordNotFound", err)
+	}
+	if err := db.Unscoped().First(&got, a.ID).Error; err != nil {
+		t.Fatalf("unscoped find: %v", err)
+	}
+	if !got.DeletedAt.Valid {
+		t.Fatal("DeletedAt not set on soft-deleted row")
+	}
+}
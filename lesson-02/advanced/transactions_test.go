@@ -0,0 +1,83 @@
+// Lesson 02 (advanced): concurrent transactions against SQLite's
+// single-writer model. The busy timeout and WAL mode configured in
+// testutil.DSN, plus RetryOnBusy, keep these tests from flaking with
+// "database is locked".
+package advanced
+
+import (
+	"sync"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/lannisite110/hello_world/lesson-02/testutil"
+)
+
+// counter is a row several goroutines increment transactionally.
+type counter struct {
+	ID    uint `gorm:"primarykey"`
+	Value int
+}
+
+func TestConcurrentTransactionsComplete(t *testing.T) {
+	db := testutil.NewTestDB(t, &counter{})
+	if err := db.Create(&counter{ID: 1, Value: 0}).Error; err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	const (
+		writers    = 8
+		increments = 10
+	)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < increments; i++ {
+				err := testutil.RetryOnBusy(10, func() error {
+					return db.Transaction(func(tx *gorm.DB) error {
+						var c counter
+						if err := tx.First(&c, 1).Error; err != nil {
+							return err
+						}
+						return tx.Model(&c).Update("value", c.Value+1).Error
+					})
+				})
+				if err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("concurrent transaction: %v", err)
+	}
+
+	var got counter
+	if err := db.First(&got, 1).Error; err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if got.Value != writers*increments {
+		t.Fatalf("value = %d, want %d (no lost increments)", got.Value, writers*increments)
+	}
+}
+
+func TestRetryOnBusyPassesOtherErrorsThrough(t *testing.T) {
+	calls := 0
+	err := testutil.RetryOnBusy(5, func() error {
+		calls++
+		return gorm.ErrRecordNotFound
+	})
+	if err != gorm.ErrRecordNotFound {
+		t.Fatalf("err = %v, want ErrRecordNotFound", err)
+	}
+	if calls != 1 {
+		t.Fatalf("fn ran %d times, want 1 (no retry on non-busy errors)", calls)
+	}
+}
@@ -0,0 +1,110 @@
+// Lesson 02 (basics): create/read/update/delete with GORM.
+package basics
+
+import (
+	"fmt"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/lannisite110/hello_world/lesson-02/gormx"
+	"github.com/lannisite110/hello_world/lesson-02/testutil"
+)
+
+// Customer is the model the basic CRUD lessons work with.
+type Customer struct {
+	ID     uint   `gorm:"primarykey"`
+	Name   string `gorm:"size:64"`
+	Email  string `gorm:"size:128;uniqueIndex"`
+	Age    int
+	Active bool
+}
+
+func TestCURDDemo(t *testing.T) {
+	db := testutil.NewTestDB(t, &Customer{})
+
+	// Create
+	seed := []Customer{
+		{Name: "Alice", Email: "alice@example.com", Age: 30, Active: true},
+		{Name: "Bob", Email: "bob@example.com", Age: 25, Active: true},
+		{Name: "Carol", Email: "carol@example.com", Age: 35, Active: false},
+	}
+	if err := db.Create(&seed).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// Read
+	var alice Customer
+	if err := db.Where("email = ?", "alice@example.com").First(&alice).Error; err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if alice.Age != 30 {
+		t.Fatalf("alice.Age = %d, want 30", alice.Age)
+	}
+
+	// Update
+	if err := db.Model(&alice).Update("age", 31).Error; err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	var updated Customer
+	db.First(&updated, alice.ID)
+	if updated.Age != 31 {
+		t.Fatalf("age after update = %d, want 31", updated.Age)
+	}
+
+	// Delete
+	if err := db.Delete(&Customer{}, alice.ID).Error; err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	var count int64
+	db.Model(&Customer{}).Count(&count)
+	if count != 2 {
+		t.Fatalf("count after delete = %d, want 2", count)
+	}
+}
+
+func TestBatchCreate(t *testing.T) {
+	db := testutil.NewTestDB(t, &Customer{})
+
+	// Count the insert statements so we can see the batching happen.
+	batches := 0
+	err := db.Callback().Create().After("gorm:create").Register("test:count_batches", func(*gorm.DB) {
+		batches++
+	})
+	if err != nil {
+		t.Fatalf("register callback: %v", err)
+	}
+
+	const total, size = 1000, 100
+	records := make([]Customer, total)
+	for i := range records {
+		records[i] = Customer{
+			Name:  fmt.Sprintf("user%04d", i),
+			Email: fmt.Sprintf("user%04d@example.com", i),
+			Age:   20 + i%50,
+		}
+	}
+	if err := gormx.BatchCreate(db, records, size); err != nil {
+		t.Fatalf("BatchCreate: %v", err)
+	}
+
+	var count int64
+	db.Model(&Customer{}).Count(&count)
+	if count != total {
+		t.Fatalf("row count = %d, want %d", count, total)
+	}
+	if batches != total/size {
+		t.Fatalf("insert ran in %d batches, want %d", batches, total/size)
+	}
+}
+
+func TestBatchCreateValidation(t *testing.T) {
+	db := testutil.NewTestDB(t, &Customer{})
+
+	if err := gormx.BatchCreate(db, []Customer{{Name: "x", Email: "x@example.com"}}, -1); err == nil {
+		t.Fatal("negative batch size accepted")
+	}
+	if err := gormx.BatchCreate[Customer](db, nil, 0); err != nil {
+		t.Fatalf("empty slice: %v", err)
+	}
+}
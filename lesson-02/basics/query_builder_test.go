@@ -0,0 +1,178 @@
+// Lesson 02 (basics): building queries with chained conditions and scopes.
+package basics
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/lannisite110/hello_world/lesson-02/gormx"
+	"github.com/lannisite110/hello_world/lesson-02/testutil"
+)
+
+// activeCustomers is a reusable query scope.
+func activeCustomers() func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("active = ?", true)
+	}
+}
+
+// ageBetween is a parameterized query scope.
+func ageBetween(min, max int) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("age BETWEEN ? AND ?", min, max)
+	}
+}
+
+func seedCustomers(t *testing.T, db *gorm.DB, n int) {
+	t.Helper()
+	records := make([]Customer, n)
+	for i := range records {
+		records[i] = Customer{
+			Name:   fmt.Sprintf("user%03d", i),
+			Email:  fmt.Sprintf("user%03d@example.com", i),
+			Age:    20 + i%40,
+			Active: i%2 == 0,
+		}
+	}
+	if err := db.Create(&records).Error; err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+}
+
+func TestQueryBuilderScopes(t *testing.T) {
+	db := testutil.NewTestDB(t, &Customer{})
+	seedCustomers(t, db, 40)
+
+	var result []Customer
+	err := db.Scopes(activeCustomers(), ageBetween(25, 30)).
+		Order("age").
+		Find(&result).Error
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if len(result) == 0 {
+		t.Fatal("no rows matched")
+	}
+	for _, c := range result {
+		if !c.Active || c.Age < 25 || c.Age > 30 {
+			t.Fatalf("row outside scope: %+v", c)
+		}
+	}
+}
+
+func TestEachBatch(t *testing.T) {
+	db := testutil.NewTestDB(t, &Customer{})
+	seedCustomers(t, db, 25)
+
+	calls := 0
+	seen := make(map[uint]int)
+	err := gormx.EachBatch(db.Model(&Customer{}), 10, func(batch []Customer) error {
+		calls++
+		for _, c := range batch {
+			seen[c.ID]++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("EachBatch: %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("fn invoked %d times, want 3 (batches of 10 over 25 rows)", calls)
+	}
+	if len(seen) != 25 {
+		t.Fatalf("saw %d distinct rows, want 25", len(seen))
+	}
+	for id, n := range seen {
+		if n != 1 {
+			t.Fatalf("row %d seen %d times", id, n)
+		}
+	}
+}
+
+func TestEachBatchStopsOnError(t *testing.T) {
+	db := testutil.NewTestDB(t, &Customer{})
+	seedCustomers(t, db, 25)
+
+	boom := errors.New("stop here")
+	calls := 0
+	err := gormx.EachBatch(db.Model(&Customer{}), 10, func(batch []Customer) error {
+		calls++
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want %v", err, boom)
+	}
+	if calls != 1 {
+		t.Fatalf("fn invoked %d times after error, want 1", calls)
+	}
+}
+
+func TestByEmailScopeCaseInsensitive(t *testing.T) {
+	db := testutil.NewTestDB(t, &Customer{})
+	if err := db.Create(&Customer{Name: "Alice", Email: "alice@example.com"}).Error; err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	var found Customer
+	err := db.Scopes(gormx.ByEmail("Alice@Example.COM")).First(&found).Error
+	if err != nil {
+		t.Fatalf("mixed-case lookup: %v", err)
+	}
+	if found.Name != "Alice" {
+		t.Fatalf("found %q, want Alice", found.Name)
+	}
+
+	err = db.Scopes(gormx.ByEmail("nobody@example.com")).First(&Customer{}).Error
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("err = %v, want ErrRecordNotFound", err)
+	}
+}
+
+func TestComposeMatchesChainedScopes(t *testing.T) {
+	db := testutil.NewTestDB(t, &Customer{})
+	seedCustomers(t, db, 40)
+
+	var chained []Customer
+	if err := db.Scopes(activeCustomers(), ageBetween(25, 30)).Order("id").Find(&chained).Error; err != nil {
+		t.Fatalf("chained query: %v", err)
+	}
+
+	var composed []Customer
+	bundle := gormx.Compose(activeCustomers(), ageBetween(25, 30))
+	if err := db.Scopes(bundle).Order("id").Find(&composed).Error; err != nil {
+		t.Fatalf("composed query: %v", err)
+	}
+
+	if len(chained) == 0 || len(composed) != len(chained) {
+		t.Fatalf("composed %d rows, chained %d", len(composed), len(chained))
+	}
+	for i := range chained {
+		if composed[i].ID != chained[i].ID {
+			t.Fatalf("row %d: composed id %d, chained id %d", i, composed[i].ID, chained[i].ID)
+		}
+	}
+}
+
+func TestWhereIfFalseIsNoOp(t *testing.T) {
+	db := testutil.NewTestDB(t, &Customer{})
+	seedCustomers(t, db, 10)
+
+	var all []Customer
+	if err := db.Scopes(gormx.WhereIf(false, "active = ?", true)).Find(&all).Error; err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if len(all) != 10 {
+		t.Fatalf("WhereIf(false) filtered rows: got %d, want 10", len(all))
+	}
+
+	var active []Customer
+	if err := db.Scopes(gormx.WhereIf(true, "active = ?", true)).Find(&active).Error; err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if len(active) != 5 {
+		t.Fatalf("WhereIf(true) matched %d rows, want 5", len(active))
+	}
+}
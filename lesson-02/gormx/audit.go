@@ -0,0 +1,35 @@
+// Package gormx holds reusable GORM building blocks (mixins, scopes,
+// callbacks) shared by the lesson-02 examples.
+package gormx
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/lannisite110/hello_world/pkg/ctxutil"
+)
+
+// AuditFields records who created and last changed a row. Embed it in a
+// model and the hooks below fill the columns from the operator stored in the
+// request context (see ctxutil.WithOperator). Without an operator on the
+// context the hooks are no-ops, so anonymous writes stay possible.
+type AuditFields struct {
+	CreatedBy string `gorm:"size:64"`
+	UpdatedBy string `gorm:"size:64"`
+}
+
+// BeforeCreate stamps both audit columns with the context operator.
+func (a *AuditFields) BeforeCreate(tx *gorm.DB) error {
+	if op, ok := ctxutil.Operator(tx.Statement.Context); ok {
+		tx.Statement.SetColumn("CreatedBy", op)
+		tx.Statement.SetColumn("UpdatedBy", op)
+	}
+	return nil
+}
+
+// BeforeUpdate stamps UpdatedBy with the context operator.
+func (a *AuditFields) BeforeUpdate(tx *gorm.DB) error {
+	if op, ok := ctxutil.Operator(tx.Statement.Context); ok {
+		tx.Statement.SetColumn("UpdatedBy", op)
+	}
+	return nil
+}
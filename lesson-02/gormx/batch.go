@@ -0,0 +1,42 @@
+package gormx
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// DefaultBatchSize is used by BatchCreate when no size is given.
+const DefaultBatchSize = 100
+
+// BatchCreate inserts records in batches of batchSize via CreateInBatches,
+// so very large slices neither blow up memory nor exceed SQL parameter
+// limits. A zero batchSize falls back to DefaultBatchSize; negative sizes
+// are rejected.
+func BatchCreate[T any](db *gorm.DB, records []T, batchSize int) error {
+	if batchSize < 0 {
+		return fmt.Errorf("batch size %d must not be negative", batchSize)
+	}
+	if batchSize == 0 {
+		batchSize = DefaultBatchSize
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	return db.CreateInBatches(records, batchSize).Error
+}
+
+// EachBatch loads rows of T in batches of batchSize and hands each batch to
+// fn, wrapping FindInBatches so huge tables can be processed with bounded
+// memory. Scanning stops early when fn returns an error, which is then
+// returned to the caller.
+func EachBatch[T any](db *gorm.DB, batchSize int, fn func([]T) error) error {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	var batch []T
+	result := db.FindInBatches(&batch, batchSize, func(tx *gorm.DB, n int) error {
+		return fn(batch)
+	})
+	return result.Error
+}
@@ -0,0 +1,38 @@
+package gormx
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ByEmail matches an email column case-insensitively. LOWER() on both sides
+// keeps it portable across SQLite, MySQL and Postgres.
+func ByEmail(email string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("LOWER(email) = ?", strings.ToLower(email))
+	}
+}
+
+// Compose merges several scopes into one, applied left to right, so a query
+// can pass a prebuilt bundle to db.Scopes instead of listing each scope at
+// every call site.
+func Compose(scopes ...func(*gorm.DB) *gorm.DB) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, scope := range scopes {
+			db = scope(db)
+		}
+		return db
+	}
+}
+
+// WhereIf applies the condition only when cond is true; otherwise it is a
+// no-op scope. Handy for optional filters driven by request parameters.
+func WhereIf(cond bool, query string, args ...any) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if !cond {
+			return db
+		}
+		return db.Where(query, args...)
+	}
+}
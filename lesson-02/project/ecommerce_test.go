@@ -0,0 +1,1050 @@
+// Lesson 02 project: a miniature e-commerce flow (orders, stock, payment)
+// exercised entirely through tests against SQLite.
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/lannisite110/hello_world/lesson-02/gormx"
+	"github.com/lannisite110/hello_world/lesson-02/testutil"
+	"github.com/lannisite110/hello_world/pkg/ctxutil"
+)
+
+// Order lifecycle states.
+const (
+	StatusPending   = "PENDING"
+	StatusPaid      = "PAID"
+	StatusCancelled = "CANCELLED"
+	StatusRefunded  = "REFUNDED"
+)
+
+// User is a buyer account.
+type User struct {
+	ID    uint   `gorm:"primarykey"`
+	Name  string `gorm:"size:64"`
+	Email string `gorm:"size:128;uniqueIndex"`
+	gormx.AuditFields
+
+	// DisplayName is derived in AfterFind and never stored.
+	DisplayName string `gorm:"-"`
+}
+
+// AfterFind computes the presentation name from the stored columns, showing
+// post-load transformation with a GORM hook.
+func (u *User) AfterFind(*gorm.DB) error {
+	u.DisplayName = fmt.Sprintf("%s <%s>", u.Name, u.Email)
+	return nil
+}
+
+// Product is a sellable item; Price is in cents.
+type Product struct {
+	ID    uint   `gorm:"primarykey" json:"id"`
+	Name  string `gorm:"size:128" json:"name"`
+	Price int64  `json:"price"`
+	Stock int    `json:"stock"`
+}
+
+// priceHistory records one price change of a product, written by the
+// Product BeforeUpdate hook.
+type priceHistory struct {
+	ID        uint  `gorm:"primarykey"`
+	ProductID uint  `gorm:"index"`
+	OldPrice  int64 // cents, before the update
+	NewPrice  int64 // cents, after the update
+	ChangedAt time.Time
+}
+
+// destPrice extracts the price an update statement is about to write, for
+// the update styles the project uses (column map or struct).
+func destPrice(dest any) (int64, bool) {
+	switch d := dest.(type) {
+	case map[string]any:
+		v, ok := d["price"]
+		if !ok {
+			v, ok = d["Price"]
+		}
+		if !ok {
+			return 0, false
+		}
+		switch n := v.(type) {
+		case int:
+			return int64(n), true
+		case int64:
+			return n, true
+		case float64:
+			return int64(n), true
+		}
+	case *Product:
+		return d.Price, true
+	case Product:
+		return d.Price, true
+	}
+	return 0, false
+}
+
+// BeforeUpdate audits price changes: whenever an update writes a different
+// price, the old and new values land in price_histories inside the same
+// transaction, so the audit can never drift from the data.
+func (p *Product) BeforeUpdate(tx *gorm.DB) error {
+	if !tx.Statement.Changed("Price") {
+		return nil
+	}
+	newPrice, ok := destPrice(tx.Statement.Dest)
+	if !ok {
+		return nil
+	}
+	// p still carries the loaded (pre-update) values here.
+	return tx.Session(&gorm.Session{NewDB: true}).Create(&priceHistory{
+		ProductID: p.ID,
+		OldPrice:  p.Price,
+		NewPrice:  newPrice,
+		ChangedAt: time.Now(),
+	}).Error
+}
+
+// PriceHistory returns a product's recorded price changes, oldest first.
+func PriceHistory(db *gorm.DB, productID uint) ([]priceHistory, error) {
+	var history []priceHistory
+	err := db.Where("product_id = ?", productID).Order("id").Find(&history).Error
+	return history, err
+}
+
+// Order is one purchase; TotalAmount is in cents.
+type Order struct {
+	ID          uint        `gorm:"primarykey" json:"id"`
+	OrderNo     string      `gorm:"size:32;uniqueIndex" json:"order_no"`
+	UserID      uint        `json:"user_id"`
+	User        User        `json:"-"`
+	Status      string      `gorm:"size:16" json:"status"`
+	TotalAmount int64       `json:"-"`
+	Items       []OrderItem `json:"items,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+	PaidAt      *time.Time  `json:"paid_at,omitempty"`
+	RefundedAt  *time.Time  `json:"refunded_at,omitempty"`
+	gormx.AuditFields
+}
+
+// OrderItem is one line of an order; UnitPrice snapshots the product price
+// at purchase time, in cents.
+type OrderItem struct {
+	ID        uint    `gorm:"primarykey" json:"id"`
+	OrderID   uint    `json:"order_id"`
+	ProductID uint    `json:"product_id"`
+	Product   Product `json:"-"`
+	Quantity  int     `json:"quantity"`
+	UnitPrice int64   `json:"-"`
+}
+
+// OrderItemInput is what callers pass to CreateOrder.
+type OrderItemInput struct {
+	ProductID uint
+	Quantity  int
+}
+
+// ErrInvalidOrderItems wraps all order-input validation failures.
+var ErrInvalidOrderItems = errors.New("invalid order items")
+
+// Validate checks one input line.
+func (in OrderItemInput) Validate() error {
+	if in.ProductID == 0 {
+		return errors.New("product id is required")
+	}
+	if in.Quantity <= 0 {
+		return fmt.Errorf("product %d: quantity must be positive, got %d", in.ProductID, in.Quantity)
+	}
+	return nil
+}
+
+// ValidateOrderItems checks a whole order's inputs: the list must be
+// non-empty, every line valid, and no product may appear twice (callers
+// should merge quantities instead). All failures come back joined under
+// ErrInvalidOrderItems.
+func ValidateOrderItems(items []OrderItemInput) error {
+	if len(items) == 0 {
+		return fmt.Errorf("%w: order needs at least one item", ErrInvalidOrderItems)
+	}
+	var errs []error
+	seen := make(map[uint]struct{}, len(items))
+	for _, in := range items {
+		if err := in.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+		if _, dup := seen[in.ProductID]; dup && in.ProductID != 0 {
+			errs = append(errs, fmt.Errorf("product %d listed more than once", in.ProductID))
+		}
+		seen[in.ProductID] = struct{}{}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidOrderItems, errors.Join(errs...))
+	}
+	return nil
+}
+
+// centsToDecimal renders cents as a two-decimal string, e.g. 13800 ->
+// "138.00".
+func centsToDecimal(c int64) string {
+	sign := ""
+	if c < 0 {
+		sign, c = "-", -c
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, c/100, c%100)
+}
+
+// decimalToCents parses a "138.00"-style string back into cents.
+func decimalToCents(s string) (int64, error) {
+	var whole, frac int64
+	var sign int64 = 1
+	if strings.HasPrefix(s, "-") {
+		sign, s = -1, s[1:]
+	}
+	switch parts := strings.SplitN(s, ".", 2); len(parts) {
+	case 1:
+		if _, err := fmt.Sscanf(parts[0], "%d", &whole); err != nil {
+			return 0, fmt.Errorf("invalid amount %q", s)
+		}
+	case 2:
+		if len(parts[1]) != 2 {
+			return 0, fmt.Errorf("amount %q must have two decimals", s)
+		}
+		if _, err := fmt.Sscanf(parts[0], "%d", &whole); err != nil {
+			return 0, fmt.Errorf("invalid amount %q", s)
+		}
+		if _, err := fmt.Sscanf(parts[1], "%d", &frac); err != nil {
+			return 0, fmt.Errorf("invalid amount %q", s)
+		}
+	}
+	return sign * (whole*100 + frac), nil
+}
+
+// orderJSON mirrors Order for serialization, with money as decimal strings.
+type orderJSON struct {
+	ID          uint        `json:"id"`
+	OrderNo     string      `json:"order_no"`
+	UserID      uint        `json:"user_id"`
+	Status      string      `json:"status"`
+	TotalAmount string      `json:"total_amount"`
+	Items       []OrderItem `json:"items,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+	PaidAt      *time.Time  `json:"paid_at,omitempty"`
+}
+
+// MarshalJSON renders TotalAmount as a decimal string while the model keeps
+// cents internally.
+func (o Order) MarshalJSON() ([]byte, error) {
+	return json.Marshal(orderJSON{
+		ID: o.ID, OrderNo: o.OrderNo, UserID: o.UserID, Status: o.Status,
+		TotalAmount: centsToDecimal(o.TotalAmount),
+		Items:       o.Items, CreatedAt: o.CreatedAt, PaidAt: o.PaidAt,
+	})
+}
+
+// UnmarshalJSON is the inverse of MarshalJSON.
+func (o *Order) UnmarshalJSON(data []byte) error {
+	var in orderJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	cents, err := decimalToCents(in.TotalAmount)
+	if err != nil {
+		return err
+	}
+	o.ID, o.OrderNo, o.UserID, o.Status = in.ID, in.OrderNo, in.UserID, in.Status
+	o.TotalAmount = cents
+	o.Items, o.CreatedAt, o.PaidAt = in.Items, in.CreatedAt, in.PaidAt
+	return nil
+}
+
+// itemJSON mirrors OrderItem with unit_price as a decimal string.
+type itemJSON struct {
+	ID        uint   `json:"id"`
+	OrderID   uint   `json:"order_id"`
+	ProductID uint   `json:"product_id"`
+	Quantity  int    `json:"quantity"`
+	UnitPrice string `json:"unit_price"`
+}
+
+// MarshalJSON renders UnitPrice as a decimal string.
+func (i OrderItem) MarshalJSON() ([]byte, error) {
+	return json.Marshal(itemJSON{
+		ID: i.ID, OrderID: i.OrderID, ProductID: i.ProductID,
+		Quantity: i.Quantity, UnitPrice: centsToDecimal(i.UnitPrice),
+	})
+}
+
+// UnmarshalJSON is the inverse of MarshalJSON.
+func (i *OrderItem) UnmarshalJSON(data []byte) error {
+	var in itemJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	cents, err := decimalToCents(in.UnitPrice)
+	if err != nil {
+		return err
+	}
+	i.ID, i.OrderID, i.ProductID, i.Quantity = in.ID, in.OrderID, in.ProductID, in.Quantity
+	i.UnitPrice = cents
+	return nil
+}
+
+// ErrInsufficientStock is returned when a product cannot cover the requested
+// quantity.
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+// generateOrderNo builds an order number from the date and a random suffix.
+// The 4-digit suffix can collide within a day; CreateOrder compensates by
+// retrying, and sequentialOrderNo is the collision-free alternative.
+func generateOrderNo() string {
+	return fmt.Sprintf("ORD%s%04d", time.Now().Format("20060102"), rand.Intn(10000))
+}
+
+// orderSeq backs sequentialOrderNo.
+var orderSeq atomic.Int64
+
+// sequentialOrderNo builds an order number from the date and an atomic
+// counter, which cannot collide within one process.
+func sequentialOrderNo() string {
+	return fmt.Sprintf("ORD%s%06d", time.Now().Format("20060102"), orderSeq.Add(1))
+}
+
+// orderNoFunc is the generator CreateOrder uses; swappable in tests and by
+// callers preferring sequential numbers.
+var orderNoFunc = generateOrderNo
+
+// maxOrderNoRetries bounds how often CreateOrder regenerates after a
+// unique-constraint collision.
+const maxOrderNoRetries = 3
+
+// isUniqueViolation reports whether err is a unique-index violation.
+func isUniqueViolation(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
+
+// CreateOrder atomically checks and decrements stock, snapshots unit prices
+// and creates the order with its items.
+func CreateOrder(db *gorm.DB, userID uint, items []OrderItemInput) (*Order, error) {
+	if err := ValidateOrderItems(items); err != nil {
+		return nil, err
+	}
+	var lastErr error
+	for attempt := 0; attempt < maxOrderNoRetries; attempt++ {
+		order := &Order{
+			OrderNo: orderNoFunc(),
+			UserID:  userID,
+			Status:  StatusPending,
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			for _, in := range items {
+				var product Product
+				if err := tx.First(&product, in.ProductID).Error; err != nil {
+					return fmt.Errorf("product %d: %w", in.ProductID, err)
+				}
+				if product.Stock < in.Quantity {
+					return fmt.Errorf("product %d: %w", in.ProductID, ErrInsufficientStock)
+				}
+				if err := tx.Model(&product).Update("stock", product.Stock-in.Quantity).Error; err != nil {
+					return err
+				}
+				order.Items = append(order.Items, OrderItem{
+					ProductID: in.ProductID,
+					Quantity:  in.Quantity,
+					UnitPrice: product.Price,
+				})
+				order.TotalAmount += product.Price * int64(in.Quantity)
+			}
+			return tx.Create(order).Error
+		})
+		if err == nil {
+			return order, nil
+		}
+		// Only an order-number collision is worth a retry; the rollback
+		// already restored the stock.
+		if !isUniqueViolation(err) {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("order number collided %d times: %w", maxOrderNoRetries, lastErr)
+}
+
+// PayOrder moves a pending order to PAID and records the payment time.
+func PayOrder(db *gorm.DB, orderNo string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var order Order
+		if err := tx.Where("order_no = ?", orderNo).First(&order).Error; err != nil {
+			return err
+		}
+		if order.Status != StatusPending {
+			return fmt.Errorf("order %s is %s, cannot pay", orderNo, order.Status)
+		}
+		now := time.Now()
+		return tx.Model(&order).Updates(map[string]any{
+			"status":  StatusPaid,
+			"paid_at": now,
+		}).Error
+	})
+}
+
+// ErrNotRefundable is returned when RefundOrder targets an order that is not
+// in the PAID state.
+var ErrNotRefundable = errors.New("order is not refundable")
+
+// RefundOrder reverses a paid order: the status moves to REFUNDED with the
+// refund time recorded, and every line's quantity goes back into stock. The
+// whole reversal runs in one transaction so a partial refund can never be
+// observed.
+func RefundOrder(ctx context.Context, db *gorm.DB, orderNo string) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var order Order
+		if err := tx.Preload("Items").Where("order_no = ?", orderNo).First(&order).Error; err != nil {
+			return err
+		}
+		if order.Status != StatusPaid {
+			return fmt.Errorf("order %s is %s: %w", orderNo, order.Status, ErrNotRefundable)
+		}
+		for _, item := range order.Items {
+			err := tx.Model(&Product{}).Where("id = ?", item.ProductID).
+				Update("stock", gorm.Expr("stock + ?", item.Quantity)).Error
+			if err != nil {
+				return err
+			}
+		}
+		now := time.Now()
+		return tx.Model(&order).Updates(map[string]any{
+			"status":      StatusRefunded,
+			"refunded_at": now,
+		}).Error
+	})
+}
+
+// fetchOrder loads one order with its items, products and user.
+func fetchOrder(db *gorm.DB, orderNo string) (*Order, error) {
+	var order Order
+	err := db.Preload("Items.Product").Preload("User").
+		Where("order_no = ?", orderNo).First(&order).Error
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// ListOrders pages through orders (newest first), preloading items and
+// products only for the returned page, and reports the total order count.
+func ListOrders(db *gorm.DB, page, size int) ([]Order, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = 10
+	}
+
+	var total int64
+	if err := db.Model(&Order{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var orders []Order
+	err := db.Preload("Items.Product").
+		Order("id DESC").
+		Offset((page - 1) * size).
+		Limit(size).
+		Find(&orders).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return orders, total, nil
+}
+
+// VerifyOrderTotal recomputes the order total from its items and compares it
+// with the stored TotalAmount, returning whether they match and the
+// recomputed value.
+func VerifyOrderTotal(db *gorm.DB, orderNo string) (bool, int64, error) {
+	var order Order
+	if err := db.Where("order_no = ?", orderNo).First(&order).Error; err != nil {
+		return false, 0, err
+	}
+	var recomputed int64
+	err := db.Model(&OrderItem{}).
+		Select("COALESCE(sum(quantity * unit_price), 0)").
+		Where("order_id = ?", order.ID).
+		Scan(&recomputed).Error
+	if err != nil {
+		return false, 0, err
+	}
+	return recomputed == order.TotalAmount, recomputed, nil
+}
+
+// SalesSummary is one day of paid sales.
+type SalesSummary struct {
+	Day        string
+	OrderCount int64
+	Total      int64
+}
+
+// SalesReport aggregates paid orders per day.
+func SalesReport(db *gorm.DB) ([]SalesSummary, error) {
+	var report []SalesSummary
+	err := db.Model(&Order{}).
+		Select("date(created_at) AS day, count(*) AS order_count, sum(total_amount) AS total").
+		Where("status = ?", StatusPaid).
+		Group("date(created_at)").
+		Order("day").
+		Scan(&report).Error
+	return report, err
+}
+
+// SalesReportRange is SalesReport restricted to orders created in
+// [from, to), keeping the daily grouping.
+func SalesReportRange(db *gorm.DB, from, to time.Time) ([]SalesSummary, error) {
+	var report []SalesSummary
+	err := db.Model(&Order{}).
+		Select("date(created_at) AS day, count(*) AS order_count, sum(total_amount) AS total").
+		Where("status = ?", StatusPaid).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Group("date(created_at)").
+		Order("day").
+		Scan(&report).Error
+	return report, err
+}
+
+// newEcommerceDB builds a fresh database with the project schema and a
+// couple of products.
+func newEcommerceDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := testutil.NewTestDB(t, &User{}, &Product{}, &Order{}, &OrderItem{},
+		&Reservation{}, &ReservationItem{}, &priceHistory{})
+	seed := []any{
+		&User{ID: 1, Name: "Alice", Email: "alice@example.com"},
+		&Product{ID: 1, Name: "Keyboard", Price: 6900, Stock: 100},
+		&Product{ID: 2, Name: "Mouse", Price: 2900, Stock: 100},
+	}
+	for _, s := range seed {
+		if err := db.Create(s).Error; err != nil {
+			t.Fatalf("seed %T: %v", s, err)
+		}
+	}
+	return db
+}
+
+func TestCreateAndPayOrder(t *testing.T) {
+	db := newEcommerceDB(t)
+
+	order, err := CreateOrder(db, 1, []OrderItemInput{
+		{ProductID: 1, Quantity: 2},
+		{ProductID: 2, Quantity: 1},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if order.TotalAmount != 2*6900+2900 {
+		t.Fatalf("total = %d, want %d", order.TotalAmount, 2*6900+2900)
+	}
+
+	var keyboard Product
+	if err := db.First(&keyboard, 1).Error; err != nil {
+		t.Fatal(err)
+	}
+	if keyboard.Stock != 98 {
+		t.Fatalf("stock = %d, want 98", keyboard.Stock)
+	}
+
+	if err := PayOrder(db, order.OrderNo); err != nil {
+		t.Fatalf("PayOrder: %v", err)
+	}
+	paid, err := fetchOrder(db, order.OrderNo)
+	if err != nil {
+		t.Fatalf("fetchOrder: %v", err)
+	}
+	if paid.Status != StatusPaid || paid.PaidAt == nil {
+		t.Fatalf("order after pay: status=%s paidAt=%v", paid.Status, paid.PaidAt)
+	}
+	if len(paid.Items) != 2 || paid.Items[0].Product.ID == 0 {
+		t.Fatalf("items not preloaded: %+v", paid.Items)
+	}
+}
+
+func TestCreateOrderInsufficientStock(t *testing.T) {
+	db := newEcommerceDB(t)
+
+	_, err := CreateOrder(db, 1, []OrderItemInput{{ProductID: 1, Quantity: 101}})
+	if !errors.Is(err, ErrInsufficientStock) {
+		t.Fatalf("err = %v, want ErrInsufficientStock", err)
+	}
+
+	// The transaction must have rolled back the stock check's order row.
+	var count int64
+	db.Model(&Order{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("order count = %d, want 0", count)
+	}
+}
+
+func TestListOrdersPagination(t *testing.T) {
+	db := newEcommerceDB(t)
+
+	const totalOrders = 7
+	orderNos := make([]string, 0, totalOrders)
+	for i := 0; i < totalOrders; i++ {
+		order, err := CreateOrder(db, 1, []OrderItemInput{{ProductID: 1, Quantity: 1}})
+		if err != nil {
+			t.Fatalf("CreateOrder %d: %v", i, err)
+		}
+		orderNos = append(orderNos, order.OrderNo)
+	}
+
+	page2, total, err := ListOrders(db, 2, 3)
+	if err != nil {
+		t.Fatalf("ListOrders: %v", err)
+	}
+	if total != totalOrders {
+		t.Fatalf("total = %d, want %d", total, totalOrders)
+	}
+	if len(page2) != 3 {
+		t.Fatalf("page has %d orders, want 3", len(page2))
+	}
+	// Newest first: page 2 of size 3 holds the 4th..2nd created orders.
+	wantNos := []string{orderNos[3], orderNos[2], orderNos[1]}
+	for i, o := range page2 {
+		if o.OrderNo != wantNos[i] {
+			t.Errorf("page2[%d] = %s, want %s", i, o.OrderNo, wantNos[i])
+		}
+		if len(o.Items) != 1 {
+			t.Errorf("order %s: %d items preloaded, want 1", o.OrderNo, len(o.Items))
+		}
+		for _, item := range o.Items {
+			if item.OrderID != o.ID {
+				t.Errorf("order %s preloaded item of order %d", o.OrderNo, item.OrderID)
+			}
+			if item.Product.ID != item.ProductID {
+				t.Errorf("order %s: product not preloaded", o.OrderNo)
+			}
+		}
+	}
+}
+
+func TestVerifyOrderTotal(t *testing.T) {
+	db := newEcommerceDB(t)
+
+	order, err := CreateOrder(db, 1, []OrderItemInput{
+		{ProductID: 1, Quantity: 3},
+		{ProductID: 2, Quantity: 2},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	ok, recomputed, err := VerifyOrderTotal(db, order.OrderNo)
+	if err != nil {
+		t.Fatalf("VerifyOrderTotal: %v", err)
+	}
+	want := 3*int64(6900) + 2*int64(2900)
+	if !ok || recomputed != want {
+		t.Fatalf("ok=%v recomputed=%d, want ok=true recomputed=%d", ok, recomputed, want)
+	}
+
+	// Corrupt the stored total and expect a mismatch.
+	if err := db.Model(&Order{}).Where("order_no = ?", order.OrderNo).
+		Update("total_amount", order.TotalAmount+1).Error; err != nil {
+		t.Fatal(err)
+	}
+	ok, recomputed, err = VerifyOrderTotal(db, order.OrderNo)
+	if err != nil {
+		t.Fatalf("VerifyOrderTotal after corruption: %v", err)
+	}
+	if ok {
+		t.Fatal("VerifyOrderTotal reported match for corrupted total")
+	}
+	if recomputed != want {
+		t.Fatalf("recomputed = %d, want %d", recomputed, want)
+	}
+}
+
+func TestOrderAuditOperator(t *testing.T) {
+	db := newEcommerceDB(t)
+
+	ctx := ctxutil.WithOperator(context.Background(), "cashier-1")
+	order, err := CreateOrder(db.WithContext(ctx), 1, []OrderItemInput{{ProductID: 1, Quantity: 1}})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	var got Order
+	if err := db.Where("order_no = ?", order.OrderNo).First(&got).Error; err != nil {
+		t.Fatal(err)
+	}
+	if got.CreatedBy != "cashier-1" || got.UpdatedBy != "cashier-1" {
+		t.Fatalf("order audit = %q/%q, want cashier-1/cashier-1", got.CreatedBy, got.UpdatedBy)
+	}
+
+	payCtx := ctxutil.WithOperator(context.Background(), "cashier-2")
+	if err := PayOrder(db.WithContext(payCtx), order.OrderNo); err != nil {
+		t.Fatalf("PayOrder: %v", err)
+	}
+	if err := db.Where("order_no = ?", order.OrderNo).First(&got).Error; err != nil {
+		t.Fatal(err)
+	}
+	if got.CreatedBy != "cashier-1" || got.UpdatedBy != "cashier-2" {
+		t.Fatalf("order audit after pay = %q/%q, want cashier-1/cashier-2", got.CreatedBy, got.UpdatedBy)
+	}
+}
+
+func TestOrderJSONMoneyRendering(t *testing.T) {
+	db := newEcommerceDB(t)
+
+	order, err := CreateOrder(db, 1, []OrderItemInput{{ProductID: 1, Quantity: 2}})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	loaded, err := fetchOrder(db, order.OrderNo)
+	if err != nil {
+		t.Fatalf("fetchOrder: %v", err)
+	}
+
+	data, err := json.Marshal(loaded)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if raw["total_amount"] != "138.00" {
+		t.Fatalf("total_amount = %v, want \"138.00\"", raw["total_amount"])
+	}
+	items := raw["items"].([]any)
+	if items[0].(map[string]any)["unit_price"] != "69.00" {
+		t.Fatalf("unit_price = %v, want \"69.00\"", items[0].(map[string]any)["unit_price"])
+	}
+
+	var back Order
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if back.TotalAmount != loaded.TotalAmount {
+		t.Fatalf("round-trip total = %d, want %d", back.TotalAmount, loaded.TotalAmount)
+	}
+	if back.Items[0].UnitPrice != 6900 {
+		t.Fatalf("round-trip unit price = %d, want 6900", back.Items[0].UnitPrice)
+	}
+}
+
+func TestDecimalToCents(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{"138.00", 13800, false},
+		{"0.05", 5, false},
+		{"-12.34", -1234, false},
+		{"7", 700, false},
+		{"1.2", 0, true},
+	}
+	for _, tc := range cases {
+		got, err := decimalToCents(tc.in)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("decimalToCents(%q) = %d, want error", tc.in, got)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Errorf("decimalToCents(%q) = %d, %v, want %d", tc.in, got, err, tc.want)
+		}
+	}
+}
+
+func TestUserAfterFindDisplayName(t *testing.T) {
+	db := newEcommerceDB(t)
+
+	var user User
+	if err := db.Where("email = ?", "alice@example.com").First(&user).Error; err != nil {
+		t.Fatalf("load user: %v", err)
+	}
+	want := fmt.Sprintf("%s <%s>", user.Name, user.Email)
+	if user.DisplayName != want {
+		t.Fatalf("DisplayName = %q, want %q", user.DisplayName, want)
+	}
+
+	// The derived field must not exist as a table column.
+	if db.Migrator().HasColumn(&User{}, "display_name") {
+		t.Fatal("display_name exists as a column")
+	}
+}
+
+func TestCreateOrderRetriesOnOrderNoCollision(t *testing.T) {
+	db := newEcommerceDB(t)
+
+	// Occupy a known order number, then make the generator emit it first.
+	taken := "ORD20990101-TAKEN"
+	if err := db.Create(&Order{OrderNo: taken, UserID: 1, Status: StatusPending}).Error; err != nil {
+		t.Fatalf("seed colliding order: %v", err)
+	}
+
+	calls := 0
+	orig := orderNoFunc
+	orderNoFunc = func() string {
+		calls++
+		if calls == 1 {
+			return taken
+		}
+		return sequentialOrderNo()
+	}
+	defer func() { orderNoFunc = orig }()
+
+	order, err := CreateOrder(db, 1, []OrderItemInput{{ProductID: 1, Quantity: 1}})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if order.OrderNo == taken {
+		t.Fatalf("order kept the colliding number %q", order.OrderNo)
+	}
+	if calls < 2 {
+		t.Fatalf("generator called %d times, want at least 2 (retry)", calls)
+	}
+
+	// The failed attempt must not have leaked a stock decrement.
+	var product Product
+	db.First(&product, 1)
+	if product.Stock != 99 {
+		t.Fatalf("stock = %d, want 99 (one item sold once)", product.Stock)
+	}
+}
+
+func TestSequentialOrderNoUnique(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		no := sequentialOrderNo()
+		if seen[no] {
+			t.Fatalf("duplicate order number %q", no)
+		}
+		seen[no] = true
+	}
+}
+
+func TestValidateOrderItems(t *testing.T) {
+	cases := []struct {
+		name    string
+		items   []OrderItemInput
+		wantErr string
+	}{
+		{"empty list", nil, "at least one item"},
+		{"zero quantity", []OrderItemInput{{ProductID: 1, Quantity: 0}}, "quantity must be positive"},
+		{"negative quantity", []OrderItemInput{{ProductID: 1, Quantity: -2}}, "quantity must be positive"},
+		{"duplicate product", []OrderItemInput{
+			{ProductID: 1, Quantity: 1},
+			{ProductID: 1, Quantity: 2},
+		}, "listed more than once"},
+		{"missing product id", []OrderItemInput{{Quantity: 1}}, "product id is required"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateOrderItems(tc.items)
+			if !errors.Is(err, ErrInvalidOrderItems) {
+				t.Fatalf("err = %v, want ErrInvalidOrderItems", err)
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("err = %v, want mention of %q", err, tc.wantErr)
+			}
+		})
+	}
+
+	ok := []OrderItemInput{{ProductID: 1, Quantity: 2}, {ProductID: 2, Quantity: 1}}
+	if err := ValidateOrderItems(ok); err != nil {
+		t.Fatalf("valid items rejected: %v", err)
+	}
+}
+
+func TestCreateOrderRejectsInvalidItems(t *testing.T) {
+	db := newEcommerceDB(t)
+
+	_, err := CreateOrder(db, 1, []OrderItemInput{{ProductID: 1, Quantity: 0}})
+	if !errors.Is(err, ErrInvalidOrderItems) {
+		t.Fatalf("err = %v, want ErrInvalidOrderItems", err)
+	}
+
+	// Validation runs before any stock is touched.
+	var keyboard Product
+	if err := db.First(&keyboard, 1).Error; err != nil {
+		t.Fatal(err)
+	}
+	if keyboard.Stock != 100 {
+		t.Fatalf("stock = %d, want untouched 100", keyboard.Stock)
+	}
+}
+
+// paidOrderOn creates and pays one order, then backdates it to day.
+func paidOrderOn(t *testing.T, db *gorm.DB, day time.Time, quantity int) {
+	t.Helper()
+	order, err := CreateOrder(db, 1, []OrderItemInput{{ProductID: 1, Quantity: quantity}})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if err := PayOrder(db, order.OrderNo); err != nil {
+		t.Fatalf("pay order: %v", err)
+	}
+	if err := db.Model(&Order{}).Where("id = ?", order.ID).
+		Update("created_at", day).Error; err != nil {
+		t.Fatalf("backdate order: %v", err)
+	}
+}
+
+func TestSalesReportRange(t *testing.T) {
+	db := newEcommerceDB(t)
+
+	day := func(offset int) time.Time {
+		return time.Date(2026, 8, 1+offset, 12, 0, 0, 0, time.UTC)
+	}
+	paidOrderOn(t, db, day(0), 1) // Aug 1: 6900
+	paidOrderOn(t, db, day(1), 2) // Aug 2: 13800
+	paidOrderOn(t, db, day(1), 1) // Aug 2: +6900
+	paidOrderOn(t, db, day(4), 3) // Aug 5: outside the range
+
+	report, err := SalesReportRange(db, day(0), day(3))
+	if err != nil {
+		t.Fatalf("SalesReportRange: %v", err)
+	}
+	if len(report) != 2 {
+		t.Fatalf("got %d days, want 2: %+v", len(report), report)
+	}
+	if report[0].Day != "2026-08-01" || report[0].OrderCount != 1 || report[0].Total != 6900 {
+		t.Fatalf("day 1 = %+v", report[0])
+	}
+	if report[1].Day != "2026-08-02" || report[1].OrderCount != 2 || report[1].Total != 20700 {
+		t.Fatalf("day 2 = %+v", report[1])
+	}
+}
+
+func TestRefundOrderRestoresStock(t *testing.T) {
+	db := newEcommerceDB(t)
+	ctx := context.Background()
+
+	order, err := CreateOrder(db, 1, []OrderItemInput{
+		{ProductID: 1, Quantity: 2},
+		{ProductID: 2, Quantity: 3},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if err := PayOrder(db, order.OrderNo); err != nil {
+		t.Fatalf("PayOrder: %v", err)
+	}
+
+	if err := RefundOrder(ctx, db, order.OrderNo); err != nil {
+		t.Fatalf("RefundOrder: %v", err)
+	}
+
+	refunded, err := fetchOrder(db, order.OrderNo)
+	if err != nil {
+		t.Fatalf("fetchOrder: %v", err)
+	}
+	if refunded.Status != StatusRefunded || refunded.RefundedAt == nil {
+		t.Fatalf("order after refund: status=%s refundedAt=%v", refunded.Status, refunded.RefundedAt)
+	}
+
+	for id, want := range map[uint]int{1: 100, 2: 100} {
+		var p Product
+		if err := db.First(&p, id).Error; err != nil {
+			t.Fatal(err)
+		}
+		if p.Stock != want {
+			t.Fatalf("product %d stock = %d, want %d restored", id, p.Stock, want)
+		}
+	}
+
+	// A second refund finds the order REFUNDED and must be rejected.
+	if err := RefundOrder(ctx, db, order.OrderNo); !errors.Is(err, ErrNotRefundable) {
+		t.Fatalf("double refund err = %v, want ErrNotRefundable", err)
+	}
+}
+
+func TestRefundOrderRejectsUnpaid(t *testing.T) {
+	db := newEcommerceDB(t)
+
+	order, err := CreateOrder(db, 1, []OrderItemInput{{ProductID: 1, Quantity: 1}})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	if err := RefundOrder(context.Background(), db, order.OrderNo); !errors.Is(err, ErrNotRefundable) {
+		t.Fatalf("err = %v, want ErrNotRefundable for a PENDING order", err)
+	}
+
+	// The failed refund must not touch stock.
+	var p Product
+	if err := db.First(&p, 1).Error; err != nil {
+		t.Fatal(err)
+	}
+	if p.Stock != 99 {
+		t.Fatalf("stock = %d, want 99 (still reserved by the pending order)", p.Stock)
+	}
+}
+
+func TestProductPriceHistory(t *testing.T) {
+	db := newEcommerceDB(t)
+
+	var keyboard Product
+	if err := db.First(&keyboard, 1).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Model(&keyboard).Update("price", int64(7900)).Error; err != nil {
+		t.Fatalf("first price update: %v", err)
+	}
+
+	if err := db.First(&keyboard, 1).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Model(&keyboard).Update("price", int64(7500)).Error; err != nil {
+		t.Fatalf("second price update: %v", err)
+	}
+
+	history, err := PriceHistory(db, 1)
+	if err != nil {
+		t.Fatalf("PriceHistory: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("got %d history rows, want 2: %+v", len(history), history)
+	}
+	if history[0].OldPrice != 6900 || history[0].NewPrice != 7900 {
+		t.Fatalf("first change = %+v, want 6900 -> 7900", history[0])
+	}
+	if history[1].OldPrice != 7900 || history[1].NewPrice != 7500 {
+		t.Fatalf("second change = %+v, want 7900 -> 7500", history[1])
+	}
+	if history[0].ChangedAt.IsZero() || history[1].ChangedAt.IsZero() {
+		t.Fatalf("history missing timestamps: %+v", history)
+	}
+}
+
+func TestPriceHistoryIgnoresOtherUpdates(t *testing.T) {
+	db := newEcommerceDB(t)
+
+	var keyboard Product
+	if err := db.First(&keyboard, 1).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Model(&keyboard).Update("stock", 50).Error; err != nil {
+		t.Fatalf("stock update: %v", err)
+	}
+	// Writing the same price again is not a change either.
+	if err := db.Model(&keyboard).Update("price", keyboard.Price).Error; err != nil {
+		t.Fatalf("same-price update: %v", err)
+	}
+
+	history, err := PriceHistory(db, 1)
+	if err != nil {
+		t.Fatalf("PriceHistory: %v", err)
+	}
+	if len(history) != 0 {
+		t.Fatalf("non-price updates recorded history: %+v", history)
+	}
+}
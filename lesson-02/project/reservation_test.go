@@ -0,0 +1,241 @@
+// Inventory reservations: stock can be held for a TTL before payment, so a
+// shopper's cart doesn't oversell while unpaid reservations still return
+// their stock automatically once they expire.
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Reservation lifecycle states.
+const (
+	ReservationHeld      = "HELD"
+	ReservationConfirmed = "CONFIRMED"
+	ReservationReleased  = "RELEASED"
+)
+
+// Reservation holds stock for a shopper until ExpiresAt.
+type Reservation struct {
+	ID        uint
+	Status    string `gorm:"size:16;index"`
+	ExpiresAt time.Time
+	Items     []ReservationItem
+	CreatedAt time.Time
+}
+
+// ReservationItem is one product line of a reservation.
+type ReservationItem struct {
+	ID            uint
+	ReservationID uint `gorm:"index"`
+	ProductID     uint
+	Quantity      int
+}
+
+var (
+	// ErrReservationNotHeld is returned when confirming a reservation that
+	// was already confirmed or released.
+	ErrReservationNotHeld = errors.New("reservation is not held")
+
+	// ErrReservationExpired is returned when confirming a reservation whose
+	// TTL has passed.
+	ErrReservationExpired = errors.New("reservation expired")
+)
+
+// reservationNow is the reservation clock; tests move it to simulate expiry.
+var reservationNow = time.Now
+
+// ReserveStock decrements stock for items and records a reservation that
+// holds it for ttl. The caller later confirms (keeps the stock) or lets the
+// reservation expire, after which ReleaseExpired restores it.
+func ReserveStock(ctx context.Context, db *gorm.DB, items []OrderItemInput, ttl time.Duration) (uint, error) {
+	reservation := &Reservation{
+		Status:    ReservationHeld,
+		ExpiresAt: reservationNow().Add(ttl),
+	}
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, in := range items {
+			var product Product
+			if err := tx.First(&product, in.ProductID).Error; err != nil {
+				return fmt.Errorf("product %d: %w", in.ProductID, err)
+			}
+			if product.Stock < in.Quantity {
+				return fmt.Errorf("product %d: %w", in.ProductID, ErrInsufficientStock)
+			}
+			if err := tx.Model(&product).Update("stock", product.Stock-in.Quantity).Error; err != nil {
+				return err
+			}
+			reservation.Items = append(reservation.Items, ReservationItem{
+				ProductID: in.ProductID,
+				Quantity:  in.Quantity,
+			})
+		}
+		return tx.Create(reservation).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return reservation.ID, nil
+}
+
+// ConfirmReservation finalizes a held, unexpired reservation: the stock stays
+// sold and the reservation leaves the pool ReleaseExpired sweeps.
+func ConfirmReservation(ctx context.Context, db *gorm.DB, reservationID uint) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var reservation Reservation
+		if err := tx.First(&reservation, reservationID).Error; err != nil {
+			return err
+		}
+		if reservation.Status != ReservationHeld {
+			return fmt.Errorf("reservation %d: %w", reservationID, ErrReservationNotHeld)
+		}
+		if reservationNow().After(reservation.ExpiresAt) {
+			return fmt.Errorf("reservation %d: %w", reservationID, ErrReservationExpired)
+		}
+		return tx.Model(&reservation).Update("status", ReservationConfirmed).Error
+	})
+}
+
+// ReleaseExpired restores the stock of every held reservation past its TTL
+// and marks it released, returning how many reservations were swept.
+func ReleaseExpired(ctx context.Context, db *gorm.DB) (int, error) {
+	released := 0
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var expired []Reservation
+		err := tx.Preload("Items").
+			Where("status = ? AND expires_at <= ?", ReservationHeld, reservationNow()).
+			Find(&expired).Error
+		if err != nil {
+			return err
+		}
+		for _, reservation := range expired {
+			for _, item := range reservation.Items {
+				err := tx.Model(&Product{}).
+					Where("id = ?", item.ProductID).
+					Update("stock", gorm.Expr("stock + ?", item.Quantity)).Error
+				if err != nil {
+					return err
+				}
+			}
+			if err := tx.Model(&reservation).Update("status", ReservationReleased).Error; err != nil {
+				return err
+			}
+			released++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return released, nil
+}
+
+// withReservationClock pins the reservation clock to a movable instant.
+func withReservationClock(t *testing.T) *time.Time {
+	t.Helper()
+	clock := time.Now()
+	reservationNow = func() time.Time { return clock }
+	t.Cleanup(func() { reservationNow = time.Now })
+	return &clock
+}
+
+func productStock(t *testing.T, db *gorm.DB, id uint) int {
+	t.Helper()
+	var product Product
+	if err := db.First(&product, id).Error; err != nil {
+		t.Fatalf("load product %d: %v", id, err)
+	}
+	return product.Stock
+}
+
+func TestReserveAndConfirm(t *testing.T) {
+	db := newEcommerceDB(t)
+	withReservationClock(t)
+	ctx := context.Background()
+
+	id, err := ReserveStock(ctx, db, []OrderItemInput{{ProductID: 1, Quantity: 5}}, time.Hour)
+	if err != nil {
+		t.Fatalf("ReserveStock: %v", err)
+	}
+	if got := productStock(t, db, 1); got != 95 {
+		t.Fatalf("stock after reserve = %d, want 95", got)
+	}
+
+	if err := ConfirmReservation(ctx, db, id); err != nil {
+		t.Fatalf("ConfirmReservation: %v", err)
+	}
+	// Confirming twice must fail: the reservation is no longer held.
+	if err := ConfirmReservation(ctx, db, id); !errors.Is(err, ErrReservationNotHeld) {
+		t.Fatalf("second confirm err = %v, want ErrReservationNotHeld", err)
+	}
+
+	// A confirmed reservation is never swept.
+	if n, err := ReleaseExpired(ctx, db); err != nil || n != 0 {
+		t.Fatalf("ReleaseExpired = %d, %v; want 0, nil", n, err)
+	}
+	if got := productStock(t, db, 1); got != 95 {
+		t.Fatalf("stock after confirm = %d, want 95", got)
+	}
+}
+
+func TestReservationExpiryRestoresStock(t *testing.T) {
+	db := newEcommerceDB(t)
+	clock := withReservationClock(t)
+	ctx := context.Background()
+
+	id, err := ReserveStock(ctx, db, []OrderItemInput{
+		{ProductID: 1, Quantity: 3},
+		{ProductID: 2, Quantity: 2},
+	}, 15*time.Minute)
+	if err != nil {
+		t.Fatalf("ReserveStock: %v", err)
+	}
+
+	// Still inside the TTL: nothing to sweep.
+	if n, err := ReleaseExpired(ctx, db); err != nil || n != 0 {
+		t.Fatalf("ReleaseExpired before expiry = %d, %v; want 0, nil", n, err)
+	}
+
+	*clock = clock.Add(time.Hour)
+
+	if err := ConfirmReservation(ctx, db, id); !errors.Is(err, ErrReservationExpired) {
+		t.Fatalf("confirm after expiry err = %v, want ErrReservationExpired", err)
+	}
+	n, err := ReleaseExpired(ctx, db)
+	if err != nil {
+		t.Fatalf("ReleaseExpired: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("released %d reservations, want 1", n)
+	}
+	if got := productStock(t, db, 1); got != 100 {
+		t.Fatalf("product 1 stock = %d, want 100 restored", got)
+	}
+	if got := productStock(t, db, 2); got != 100 {
+		t.Fatalf("product 2 stock = %d, want 100 restored", got)
+	}
+
+	// The sweep is idempotent.
+	if n, err := ReleaseExpired(ctx, db); err != nil || n != 0 {
+		t.Fatalf("second ReleaseExpired = %d, %v; want 0, nil", n, err)
+	}
+}
+
+func TestReserveStockInsufficient(t *testing.T) {
+	db := newEcommerceDB(t)
+	withReservationClock(t)
+
+	_, err := ReserveStock(context.Background(), db,
+		[]OrderItemInput{{ProductID: 1, Quantity: 101}}, time.Hour)
+	if !errors.Is(err, ErrInsufficientStock) {
+		t.Fatalf("err = %v, want ErrInsufficientStock", err)
+	}
+	if got := productStock(t, db, 1); got != 100 {
+		t.Fatalf("stock = %d, want 100 untouched", got)
+	}
+}
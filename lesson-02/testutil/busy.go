@@ -0,0 +1,38 @@
+package testutil
+
+import (
+	"strings"
+	"time"
+)
+
+// DSN decorates a SQLite file path with the pragmas the tests rely on: a
+// busy timeout so concurrent writers wait instead of failing immediately
+// with SQLITE_BUSY, and WAL journaling so readers don't block the writer.
+func DSN(path string) string {
+	return path + "?_busy_timeout=5000&_journal_mode=WAL"
+}
+
+// isBusy reports whether err is SQLite's single-writer contention error.
+func isBusy(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "database is locked") ||
+		strings.Contains(msg, "SQLITE_BUSY") ||
+		strings.Contains(msg, "database table is locked")
+}
+
+// RetryOnBusy runs fn up to attempts times, backing off briefly between
+// tries, as long as it keeps failing with SQLITE_BUSY. Any other error — or
+// success — returns immediately.
+func RetryOnBusy(attempts int, fn func() error) error {
+	var err error
+	for i := 0; i < attempts; i++ {
+		if err = fn(); !isBusy(err) {
+			return err
+		}
+		time.Sleep(time.Duration(i+1) * 5 * time.Millisecond)
+	}
+	return err
+}
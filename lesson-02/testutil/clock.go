@@ -0,0 +1,30 @@
+package testutil
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Clock is a movable source of time for tests: GORM stamps CreatedAt and
+// UpdatedAt from it, so assertions on timestamps become deterministic.
+type Clock struct {
+	now time.Time
+}
+
+// NewClock returns a clock frozen at now.
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+// Now returns the frozen instant.
+func (c *Clock) Now() time.Time { return c.now }
+
+// Advance moves the clock forward by d.
+func (c *Clock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+// WithClock makes the test database take its time from clock instead of
+// time.Now, e.g. NewTestDB(t, testutil.WithClock(clock), &User{}).
+func WithClock(clock *Clock) DBOption {
+	return func(cfg *gorm.Config) { cfg.NowFunc = clock.Now }
+}
@@ -0,0 +1,44 @@
+package testutil
+
+import (
+	"testing"
+	"time"
+)
+
+// stamped has the auto-managed timestamp columns.
+type stamped struct {
+	ID        uint `gorm:"primarykey"`
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func TestFrozenClockTimestamps(t *testing.T) {
+	frozen := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	clock := NewClock(frozen)
+	db := NewTestDB(t, WithClock(clock), &stamped{})
+
+	s := stamped{Name: "first"}
+	if err := db.Create(&s).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if !s.CreatedAt.Equal(frozen) || !s.UpdatedAt.Equal(frozen) {
+		t.Fatalf("created/updated = %v/%v, want both %v", s.CreatedAt, s.UpdatedAt, frozen)
+	}
+
+	clock.Advance(90 * time.Minute)
+	if err := db.Model(&s).Update("name", "second").Error; err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	var got stamped
+	if err := db.First(&got, s.ID).Error; err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if !got.CreatedAt.Equal(frozen) {
+		t.Fatalf("CreatedAt = %v, want unchanged %v", got.CreatedAt, frozen)
+	}
+	if want := frozen.Add(90 * time.Minute); !got.UpdatedAt.Equal(want) {
+		t.Fatalf("UpdatedAt = %v, want advanced %v", got.UpdatedAt, want)
+	}
+}
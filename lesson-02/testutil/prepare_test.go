@@ -0,0 +1,55 @@
+package testutil
+
+import "testing"
+
+// PrepareStmt interacts subtly with some SQLite operations, so make sure the
+// whole CRUD cycle still works with the statement cache enabled.
+func TestPrepareStmtCRUD(t *testing.T) {
+	db := NewTestDB(t, PrepareStmt(), &widget{})
+
+	w := widget{Name: "sprocket"}
+	if err := db.Create(&w).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	var got widget
+	if err := db.First(&got, w.ID).Error; err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if got.Name != "sprocket" {
+		t.Fatalf("name = %q, want %q", got.Name, "sprocket")
+	}
+
+	if err := db.Model(&got).Update("name", "flange").Error; err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	// Re-running the same SELECT hits the cached statement.
+	if err := db.First(&got, w.ID).Error; err != nil {
+		t.Fatalf("re-read: %v", err)
+	}
+	if got.Name != "flange" {
+		t.Fatalf("name after update = %q, want %q", got.Name, "flange")
+	}
+
+	if err := db.Delete(&widget{}, w.ID).Error; err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	var count int64
+	db.Model(&widget{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("count after delete = %d, want 0", count)
+	}
+}
+
+func benchmarkInserts(b *testing.B, opts ...any) {
+	db := NewTestDB(b, append(opts, &widget{})...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := db.Create(&widget{Name: "bench"}).Error; err != nil {
+			b.Fatalf("create: %v", err)
+		}
+	}
+}
+
+func BenchmarkInsert(b *testing.B)         { benchmarkInserts(b) }
+func BenchmarkInsertPrepared(b *testing.B) { benchmarkInserts(b, PrepareStmt()) }
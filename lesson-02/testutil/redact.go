@@ -0,0 +1,134 @@
+package testutil
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// redactedValue replaces sensitive bound parameters in logged SQL.
+const redactedValue = "[redacted]"
+
+// assignmentRe matches `column = ?` pairs in SET and WHERE clauses; the
+// placeholder position ties the column name to its bound parameter.
+var assignmentRe = regexp.MustCompile("[\"`]?(\\w+)[\"`]?\\s*=\\s*\\?")
+
+// insertRe captures the column list of an INSERT, whose order maps onto the
+// VALUES placeholders.
+var insertRe = regexp.MustCompile(`(?i)insert\s+into\s+\S+\s*\(([^)]*)\)\s*values`)
+
+// RedactingLogger is a GORM logger.Interface that prints executed SQL but
+// replaces bound parameters of sensitive columns (password, secret, token by
+// default) with a placeholder, so credentials never land in logs. It plugs
+// into GORM's ParamsFilter hook, redacting before the SQL is interpolated.
+type RedactingLogger struct {
+	logf      func(format string, args ...any)
+	sensitive map[string]bool
+}
+
+// NewRedactingLogger logs through logf and redacts the given column names
+// (case-insensitive); with none given it protects password, secret and token.
+func NewRedactingLogger(logf func(format string, args ...any), columns ...string) *RedactingLogger {
+	if len(columns) == 0 {
+		columns = []string{"password", "secret", "token"}
+	}
+	sensitive := make(map[string]bool, len(columns))
+	for _, col := range columns {
+		sensitive[strings.ToLower(col)] = true
+	}
+	return &RedactingLogger{logf: logf, sensitive: sensitive}
+}
+
+// WithLogger installs a custom GORM logger on the test database, e.g.
+// NewTestDB(t, WithLogger(NewRedactingLogger(t.Logf)), &User{}).
+func WithLogger(l logger.Interface) DBOption {
+	return func(cfg *gorm.Config) { cfg.Logger = l }
+}
+
+// sensitivePositions reports which placeholder indexes of sql bind a
+// sensitive column, from `col = ?` pairs and INSERT column lists.
+func (l *RedactingLogger) sensitivePositions(sql string) map[int]bool {
+	positions := make(map[int]bool)
+
+	// Offsets of every placeholder, so a match can be tied to its ordinal.
+	var offsets []int
+	for i, r := range sql {
+		if r == '?' {
+			offsets = append(offsets, i)
+		}
+	}
+	ordinal := func(offset int) int {
+		for i, o := range offsets {
+			if o == offset {
+				return i
+			}
+		}
+		return -1
+	}
+
+	for _, m := range assignmentRe.FindAllStringSubmatchIndex(sql, -1) {
+		col := strings.ToLower(sql[m[2]:m[3]])
+		if l.sensitive[col] {
+			positions[ordinal(m[1]-1)] = true
+		}
+	}
+
+	if m := insertRe.FindStringSubmatchIndex(sql); m != nil {
+		cols := strings.Split(sql[m[2]:m[3]], ",")
+		for i := range cols {
+			cols[i] = strings.ToLower(strings.Trim(strings.TrimSpace(cols[i]), "\"`"))
+		}
+		// Placeholders after the column list are VALUES; multi-row inserts
+		// repeat the column cycle.
+		for i, offset := range offsets {
+			if offset > m[3] && l.sensitive[cols[i%len(cols)]] {
+				positions[i] = true
+			}
+		}
+	}
+	return positions
+}
+
+// ParamsFilter implements gorm.ParamsFilter: GORM calls it before the SQL is
+// explained, so the redaction applies to what Trace later prints.
+func (l *RedactingLogger) ParamsFilter(ctx context.Context, sql string, params ...any) (string, []any) {
+	positions := l.sensitivePositions(sql)
+	if len(positions) == 0 {
+		return sql, params
+	}
+	filtered := make([]any, len(params))
+	copy(filtered, params)
+	for i := range filtered {
+		if positions[i] {
+			filtered[i] = redactedValue
+		}
+	}
+	return sql, filtered
+}
+
+func (l *RedactingLogger) LogMode(logger.LogLevel) logger.Interface { return l }
+
+func (l *RedactingLogger) Info(ctx context.Context, format string, args ...any) {
+	l.logf(format, args...)
+}
+
+func (l *RedactingLogger) Warn(ctx context.Context, format string, args ...any) {
+	l.logf(format, args...)
+}
+
+func (l *RedactingLogger) Error(ctx context.Context, format string, args ...any) {
+	l.logf(format, args...)
+}
+
+func (l *RedactingLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	sql, rows := fc()
+	if err != nil {
+		l.logf("[%.3fms] [rows:%d] %s | %v", float64(time.Since(begin).Microseconds())/1000, rows, sql, err)
+		return
+	}
+	l.logf("[%.3fms] [rows:%d] %s", float64(time.Since(begin).Microseconds())/1000, rows, sql)
+}
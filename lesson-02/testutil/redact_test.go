@@ -0,0 +1,100 @@
+package testutil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type account struct {
+	ID       uint `gorm:"primarykey"`
+	Name     string
+	Password string
+	Token    string
+}
+
+// captureLog collects everything the logger prints.
+func captureLog() (*[]string, func(format string, args ...any)) {
+	var lines []string
+	return &lines, func(format string, args ...any) {
+		lines = append(lines, fmt.Sprintf(format, args...))
+	}
+}
+
+func joined(lines *[]string) string { return strings.Join(*lines, "\n") }
+
+func TestRedactingLoggerInsert(t *testing.T) {
+	lines, logf := captureLog()
+	db := NewTestDB(t, WithLogger(NewRedactingLogger(logf)), &account{})
+
+	acct := account{Name: "alice", Password: "hunter2", Token: "tok-abc"}
+	if err := db.Create(&acct).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	out := joined(lines)
+	if !strings.Contains(out, "INSERT INTO") {
+		t.Fatalf("log %q missing the SQL", out)
+	}
+	for _, secret := range []string{"hunter2", "tok-abc"} {
+		if strings.Contains(out, secret) {
+			t.Fatalf("log leaked sensitive value %q:\n%s", secret, out)
+		}
+	}
+	if !strings.Contains(out, redactedValue) {
+		t.Fatalf("log %q has no redaction marker", out)
+	}
+	if !strings.Contains(out, "alice") {
+		t.Fatalf("log %q over-redacted: the name should stay", out)
+	}
+
+	// The real values must still have reached the database.
+	var got account
+	if err := db.First(&got, acct.ID).Error; err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if got.Password != "hunter2" || got.Token != "tok-abc" {
+		t.Fatalf("stored values were mangled: %+v", got)
+	}
+}
+
+func TestRedactingLoggerUpdateAndWhere(t *testing.T) {
+	lines, logf := captureLog()
+	db := NewTestDB(t, WithLogger(NewRedactingLogger(logf)), &account{})
+
+	acct := account{Name: "bob", Password: "old-pass"}
+	if err := db.Create(&acct).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := db.Model(&acct).Update("password", "new-pass").Error; err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	var found account
+	if err := db.Where("password = ?", "new-pass").First(&found).Error; err != nil {
+		t.Fatalf("query: %v", err)
+	}
+
+	out := joined(lines)
+	for _, secret := range []string{"old-pass", "new-pass"} {
+		if strings.Contains(out, secret) {
+			t.Fatalf("log leaked sensitive value %q:\n%s", secret, out)
+		}
+	}
+}
+
+func TestRedactingLoggerCustomColumns(t *testing.T) {
+	lines, logf := captureLog()
+	db := NewTestDB(t, WithLogger(NewRedactingLogger(logf, "name")), &account{})
+
+	if err := db.Create(&account{Name: "carol", Password: "visible"}).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	out := joined(lines)
+	if strings.Contains(out, "carol") {
+		t.Fatalf("log leaked configured column value:\n%s", out)
+	}
+	if !strings.Contains(out, "visible") {
+		t.Fatalf("log %q redacted a column that was not configured", out)
+	}
+}
@@ -0,0 +1,40 @@
+package testutil
+
+import (
+	"path/filepath"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
+)
+
+// NewTestDBWithReplica opens a test database with a read replica registered
+// through dbresolver, so SELECTs route to the replica connection and writes
+// to the primary. With SQLite both connections point at the same file — the
+// data is shared — but the routing plumbing is the real plugin, the same way
+// a MySQL or Postgres setup would split primary and replica DSNs.
+func NewTestDBWithReplica(t *testing.T, models ...any) *gorm.DB {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "test.db")
+	db, err := gorm.Open(sqlite.Open(DSN(path)), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	err = db.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: []gorm.Dialector{sqlite.Open(DSN(path))},
+	}))
+	if err != nil {
+		t.Fatalf("register read replica: %v", err)
+	}
+	if len(models) > 0 {
+		if err := db.AutoMigrate(models...); err != nil {
+			t.Fatalf("migrate test db: %v", err)
+		}
+	}
+	return db
+}
@@ -0,0 +1,50 @@
+package testutil
+
+import (
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+func TestReplicaReadAfterWrite(t *testing.T) {
+	db := NewTestDBWithReplica(t, &widget{})
+
+	if err := db.Create(&widget{Name: "gear"}).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// The default policy sends this SELECT to the replica; since both
+	// connections share the file, the written row must be visible.
+	var got widget
+	if err := db.Where("name = ?", "gear").First(&got).Error; err != nil {
+		t.Fatalf("read after write: %v", err)
+	}
+	if got.Name != "gear" {
+		t.Fatalf("name = %q, want %q", got.Name, "gear")
+	}
+
+	// Forcing the primary for the read works too.
+	var fromPrimary widget
+	err := db.Clauses(dbresolver.Write).Where("name = ?", "gear").First(&fromPrimary).Error
+	if err != nil {
+		t.Fatalf("read from primary: %v", err)
+	}
+}
+
+func TestReplicaRoutingInsideTransaction(t *testing.T) {
+	db := NewTestDBWithReplica(t, &widget{})
+
+	// Inside a transaction everything runs on the primary connection, so an
+	// uncommitted write is readable within the same transaction.
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&widget{Name: "cog"}).Error; err != nil {
+			return err
+		}
+		var got widget
+		return tx.Where("name = ?", "cog").First(&got).Error
+	})
+	if err != nil {
+		t.Fatalf("transactional read after write: %v", err)
+	}
+}
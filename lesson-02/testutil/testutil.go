@@ -0,0 +1,54 @@
+// Package testutil provides the shared database setup for the lesson-02
+// GORM tests.
+package testutil
+
+import (
+	"path/filepath"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// DBOption tweaks the gorm.Config a test database opens with. Options are
+// passed to NewTestDB alongside the models.
+type DBOption func(*gorm.Config)
+
+// PrepareStmt enables GORM's prepared-statement cache, so repeated queries
+// reuse compiled statements instead of re-parsing the SQL each time.
+func PrepareStmt() DBOption {
+	return func(cfg *gorm.Config) { cfg.PrepareStmt = true }
+}
+
+// NewTestDB opens a file-backed SQLite database in a per-test temp directory
+// and migrates the given models. The file is cleaned up with the test.
+// DBOption values may be mixed into the argument list to adjust the
+// gorm.Config, e.g. NewTestDB(t, PrepareStmt(), &User{}).
+func NewTestDB(t testing.TB, modelsAndOptions ...any) *gorm.DB {
+	t.Helper()
+
+	cfg := &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	}
+	var models []any
+	for _, arg := range modelsAndOptions {
+		if opt, ok := arg.(DBOption); ok {
+			opt(cfg)
+			continue
+		}
+		models = append(models, arg)
+	}
+
+	path := filepath.Join(t.TempDir(), "test.db")
+	db, err := gorm.Open(sqlite.Open(DSN(path)), cfg)
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	if len(models) > 0 {
+		if err := db.AutoMigrate(models...); err != nil {
+			t.Fatalf("migrate test db: %v", err)
+		}
+	}
+	return db
+}
@@ -0,0 +1,91 @@
+package testutil
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// QueryTiming records the SQL text and wall-clock duration of one statement.
+type QueryTiming struct {
+	SQL      string
+	Duration time.Duration
+}
+
+// QueryTimer is a GORM callback-based plugin that times every executed
+// statement. Statements slower than the threshold are reported through logf;
+// all timings are kept so tests can assert on them.
+type QueryTimer struct {
+	threshold time.Duration
+	logf      func(format string, args ...any)
+
+	mu      sync.Mutex
+	timings []QueryTiming
+}
+
+// NewQueryTimer returns a timer that logs statements slower than threshold
+// via logf (t.Logf fits the signature). A nil logf disables logging.
+func NewQueryTimer(threshold time.Duration, logf func(format string, args ...any)) *QueryTimer {
+	if logf == nil {
+		logf = func(string, ...any) {}
+	}
+	return &QueryTimer{threshold: threshold, logf: logf}
+}
+
+const timerStartKey = "testutil:query_start"
+
+// Register hooks the timer into every statement family of db.
+func (qt *QueryTimer) Register(db *gorm.DB) error {
+	before := func(tx *gorm.DB) {
+		tx.InstanceSet(timerStartKey, time.Now())
+	}
+	after := func(tx *gorm.DB) {
+		v, ok := tx.InstanceGet(timerStartKey)
+		if !ok {
+			return
+		}
+		start, ok := v.(time.Time)
+		if !ok {
+			return
+		}
+		qt.record(tx.Statement.SQL.String(), time.Since(start))
+	}
+
+	// The processor types returned by Callback() are unexported, so each
+	// statement family is registered explicitly.
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("*").Register("testutil:time_create_before", before),
+		cb.Create().After("*").Register("testutil:time_create_after", after),
+		cb.Query().Before("*").Register("testutil:time_query_before", before),
+		cb.Query().After("*").Register("testutil:time_query_after", after),
+		cb.Update().Before("*").Register("testutil:time_update_before", before),
+		cb.Update().After("*").Register("testutil:time_update_after", after),
+		cb.Delete().Before("*").Register("testutil:time_delete_before", before),
+		cb.Delete().After("*").Register("testutil:time_delete_after", after),
+		cb.Row().Before("*").Register("testutil:time_row_before", before),
+		cb.Row().After("*").Register("testutil:time_row_after", after),
+		cb.Raw().Before("*").Register("testutil:time_raw_before", before),
+		cb.Raw().After("*").Register("testutil:time_raw_after", after),
+	)
+}
+
+func (qt *QueryTimer) record(sql string, d time.Duration) {
+	qt.mu.Lock()
+	qt.timings = append(qt.timings, QueryTiming{SQL: sql, Duration: d})
+	qt.mu.Unlock()
+	if d > qt.threshold {
+		qt.logf("slow query (%s): %s", d, sql)
+	}
+}
+
+// Timings returns a copy of everything recorded so far.
+func (qt *QueryTimer) Timings() []QueryTiming {
+	qt.mu.Lock()
+	defer qt.mu.Unlock()
+	out := make([]QueryTiming, len(qt.timings))
+	copy(out, qt.timings)
+	return out
+}
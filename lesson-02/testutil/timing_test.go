@@ -0,0 +1,46 @@
+package testutil
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type widget struct {
+	ID   uint   `gorm:"primarykey"`
+	Name string `gorm:"size:64"`
+}
+
+func TestQueryTimerCapturesTimings(t *testing.T) {
+	db := NewTestDB(t, &widget{})
+
+	qt := NewQueryTimer(time.Millisecond, t.Logf)
+	if err := qt.Register(db); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	if err := db.Create(&widget{Name: "gear"}).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	var all []widget
+	if err := db.Find(&all).Error; err != nil {
+		t.Fatalf("find: %v", err)
+	}
+
+	timings := qt.Timings()
+	if len(timings) < 2 {
+		t.Fatalf("captured %d timings, want at least 2", len(timings))
+	}
+	foundSelect := false
+	for _, tm := range timings {
+		if tm.Duration <= 0 {
+			t.Fatalf("timing for %q has non-positive duration %v", tm.SQL, tm.Duration)
+		}
+		if strings.HasPrefix(tm.SQL, "SELECT") {
+			foundSelect = true
+		}
+	}
+	if !foundSelect {
+		t.Fatalf("no SELECT timing captured: %+v", timings)
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func doRequest(r *gin.Engine, method, path string, headers map[string]string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthOnlyGuardsAPIRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := buildRouter(&bytes.Buffer{})
+
+	if w := doRequest(r, http.MethodGet, "/ping", nil); w.Code != http.StatusOK {
+		t.Fatalf("/ping without auth = %d, want 200", w.Code)
+	}
+	if w := doRequest(r, http.MethodGet, "/api/hello", nil); w.Code != http.StatusUnauthorized {
+		t.Fatalf("/api/hello without auth = %d, want 401", w.Code)
+	}
+	w := doRequest(r, http.MethodGet, "/api/hello", map[string]string{
+		"Authorization": "Bearer " + demoToken,
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("/api/hello with auth = %d, want 200", w.Code)
+	}
+}
+
+func TestLoggerCapturesFinalStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var buf bytes.Buffer
+	r := buildRouter(&buf)
+
+	doRequest(r, http.MethodGet, "/api/hello", nil) // 401 from auth
+
+	line := buf.String()
+	if !strings.Contains(line, "-> 401") {
+		t.Fatalf("log line %q does not record downstream 401", line)
+	}
+}
+
+func TestRecoveryConvertsPanicTo500(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := buildRouter(&bytes.Buffer{})
+
+	w := doRequest(r, http.MethodGet, "/api/panic", map[string]string{
+		"Authorization": "Bearer " + demoToken,
+	})
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("panic route = %d, want 500", w.Code)
+	}
+	var body map[string]string
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("response is not JSON: %v (%s)", err, w.Body.String())
+	}
+	if body["error"] == "" {
+		t.Fatalf("body = %v, want error field", body)
+	}
+}
+
+func TestRateLimitersAreIndependent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/small", rateLimit(RateLimitConfig{Requests: 2, Window: time.Minute}), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	r.GET("/big", rateLimit(RateLimitConfig{Requests: 5, Window: time.Minute}), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	hit := func(path string) int {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w.Code
+	}
+
+	// Exhaust the small limiter.
+	for i := 0; i < 2; i++ {
+		if code := hit("/small"); code != http.StatusOK {
+			t.Fatalf("small request %d: status %d", i+1, code)
+		}
+	}
+	if code := hit("/small"); code != http.StatusTooManyRequests {
+		t.Fatalf("small over budget: status %d, want 429", code)
+	}
+
+	// The big limiter has its own counters and a bigger budget: all five
+	// requests pass even though the small one is already exhausted.
+	for i := 0; i < 5; i++ {
+		if code := hit("/big"); code != http.StatusOK {
+			t.Fatalf("big request %d: status %d (limiters sharing state?)", i+1, code)
+		}
+	}
+	if code := hit("/big"); code != http.StatusTooManyRequests {
+		t.Fatalf("big over budget: status %d, want 429", code)
+	}
+}
+
+func TestRecoveryLogsStackAndRequestContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var logged bytes.Buffer
+	logf := func(format string, args ...any) {
+		fmt.Fprintf(&logged, format+"\n", args...)
+	}
+
+	r := gin.New()
+	r.Use(recoveryMiddleware(logf))
+	r.GET("/boom", func(c *gin.Context) {
+		panic("kaboom")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/boom", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", w.Code)
+	}
+	// The client must not see the panic value, only the sanitized error.
+	if strings.Contains(w.Body.String(), "kaboom") {
+		t.Fatalf("panic detail leaked to client: %s", w.Body.String())
+	}
+
+	out := logged.String()
+	for _, want := range []string{"kaboom", "method=GET", "path=/boom", "request_id=req-123", "goroutine"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("log missing %q:\n%s", want, out)
+		}
+	}
+}
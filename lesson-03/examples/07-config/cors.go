@@ -0,0 +1,61 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORSConfig is the cross-origin section of the application config.
+type CORSConfig struct {
+	AllowedOrigins   []string `mapstructure:"allowed_origins"`
+	AllowedMethods   []string `mapstructure:"allowed_methods"`
+	AllowedHeaders   []string `mapstructure:"allowed_headers"`
+	AllowCredentials bool     `mapstructure:"allow_credentials"`
+	MaxAge           int      `mapstructure:"max_age"` // seconds
+}
+
+// CORSFromConfig builds CORS middleware from the config section. Only
+// origins in the allowlist are reflected; "*" allows any origin. Requests
+// from other origins pass through without CORS headers, so browsers block
+// them.
+func CORSFromConfig(cfg CORSConfig) gin.HandlerFunc {
+	allowed := make(map[string]bool, len(cfg.AllowedOrigins))
+	allowAny := false
+	for _, o := range cfg.AllowedOrigins {
+		if o == "*" {
+			allowAny = true
+		}
+		allowed[o] = true
+	}
+	methods := strings.Join(cfg.AllowedMethods, ", ")
+	headers := strings.Join(cfg.AllowedHeaders, ", ")
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin != "" && (allowAny || allowed[origin]) {
+			h := c.Writer.Header()
+			h.Set("Access-Control-Allow-Origin", origin)
+			h.Add("Vary", "Origin")
+			if methods != "" {
+				h.Set("Access-Control-Allow-Methods", methods)
+			}
+			if headers != "" {
+				h.Set("Access-Control-Allow-Headers", headers)
+			}
+			if cfg.AllowCredentials {
+				h.Set("Access-Control-Allow-Credentials", "true")
+			}
+			if cfg.MaxAge > 0 {
+				h.Set("Access-Control-Max-Age", strconv.Itoa(cfg.MaxAge))
+			}
+		}
+		if c.Request.Method == http.MethodOptions {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func corsTestRouter(cfg CORSConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(CORSFromConfig(cfg))
+	r.GET("/data", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	return r
+}
+
+func TestCORSAllowlistedOrigin(t *testing.T) {
+	cfg := CORSConfig{
+		AllowedOrigins:   []string{"http://localhost:3000"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"Authorization"},
+		AllowCredentials: true,
+		MaxAge:           600,
+	}
+	r := corsTestRouter(cfg)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/data", nil)
+	req.Header.Set("Origin", "http://localhost:3000")
+	r.ServeHTTP(w, req)
+
+	h := w.Header()
+	if got := h.Get("Access-Control-Allow-Origin"); got != "http://localhost:3000" {
+		t.Fatalf("Allow-Origin = %q, want reflected origin", got)
+	}
+	if got := h.Get("Access-Control-Allow-Methods"); got != "GET, POST" {
+		t.Errorf("Allow-Methods = %q", got)
+	}
+	if got := h.Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Errorf("Allow-Credentials = %q, want true", got)
+	}
+	if got := h.Get("Access-Control-Max-Age"); got != "600" {
+		t.Errorf("Max-Age = %q, want 600", got)
+	}
+}
+
+func TestCORSUnknownOriginNotReflected(t *testing.T) {
+	r := corsTestRouter(CORSConfig{AllowedOrigins: []string{"http://localhost:3000"}})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/data", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Fatalf("Allow-Origin = %q, want empty for unknown origin", got)
+	}
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (browser enforces CORS, not the server)", w.Code)
+	}
+}
+
+func TestCORSPreflight(t *testing.T) {
+	r := corsTestRouter(CORSConfig{
+		AllowedOrigins: []string{"http://localhost:3000"},
+		AllowedMethods: []string{"GET"},
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodOptions, "/data", nil)
+	req.Header.Set("Origin", "http://localhost:3000")
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("preflight status = %d, want 204", w.Code)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "http://localhost:3000" {
+		t.Fatalf("preflight Allow-Origin = %q", got)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// NewConfigFromPath loads a config file whose format is detected from the
+// extension (yaml/yml/json/toml). Environment variables with the APP_ prefix
+// override file values, including nested keys: APP_DATABASE_PASSWORD wins
+// over database.password. The loader uses its own viper instance, so it has
+// no package-level state and is safe to call from tests.
+func NewConfigFromPath(path string) (*Config, error) {
+	ext := strings.TrimPrefix(filepath.Ext(path), ".")
+	switch ext {
+	case "yaml", "yml", "json", "toml":
+	default:
+		return nil, fmt.Errorf("unsupported config format %q (want yaml, json or toml)", ext)
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType(ext)
+	v.SetEnvPrefix("APP")
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("read config %s: %w", path, err)
+	}
+
+	// Unmarshal does not consult the environment on its own; re-setting each
+	// known key through Get applies the env overrides first.
+	for _, key := range v.AllKeys() {
+		v.Set(key, v.Get(key))
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unmarshal config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+const yamlConfig = `
+server:
+  host: 127.0.0.1
+  port: 9090
+database:
+  host: db.internal
+  password: from-file
+`
+
+const jsonConfig = `{
+  "server": {"host": "127.0.0.1", "port": 9090},
+  "database": {"host": "db.internal", "password": "from-file"}
+}`
+
+func TestNewConfigFromPathYAMLAndJSON(t *testing.T) {
+	for name, content := range map[string]string{
+		"config.yaml": yamlConfig,
+		"config.json": jsonConfig,
+	} {
+		path := writeConfig(t, name, content)
+		cfg, err := NewConfigFromPath(path)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if cfg.Server.Host != "127.0.0.1" || cfg.Server.Port != 9090 {
+			t.Errorf("%s: server = %+v", name, cfg.Server)
+		}
+		if cfg.Database.Host != "db.internal" || cfg.Database.Password != "from-file" {
+			t.Errorf("%s: database = %+v", name, cfg.Database)
+		}
+	}
+}
+
+func TestEnvOverridesNestedKey(t *testing.T) {
+	t.Setenv("APP_DATABASE_PASSWORD", "from-env")
+
+	path := writeConfig(t, "config.yaml", yamlConfig)
+	cfg, err := NewConfigFromPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Database.Password != "from-env" {
+		t.Fatalf("password = %q, want env override", cfg.Database.Password)
+	}
+	if cfg.Database.Host != "db.internal" {
+		t.Fatalf("host = %q, want file value untouched", cfg.Database.Host)
+	}
+}
+
+func TestUnsupportedExtension(t *testing.T) {
+	if _, err := NewConfigFromPath("config.ini"); err == nil {
+		t.Fatal("expected error for unsupported format")
+	}
+}
@@ -0,0 +1,73 @@
+// Lesson 03-07: application configuration with Viper.
+//
+//	go run .
+//	curl localhost:8080/config
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Config is the full application configuration.
+type Config struct {
+	Server   ServerConfig   `mapstructure:"server"`
+	Database DatabaseConfig `mapstructure:"database"`
+	JWT      JWTConfig      `mapstructure:"jwt"`
+	Log      LogConfig      `mapstructure:"log"`
+	CORS     CORSConfig     `mapstructure:"cors"`
+}
+
+// ServerConfig controls the HTTP listener.
+type ServerConfig struct {
+	Host string `mapstructure:"host"`
+	Port int    `mapstructure:"port"`
+}
+
+// DatabaseConfig holds connection settings.
+type DatabaseConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	User     string `mapstructure:"user"`
+	Password string `mapstructure:"password" secret:"true"`
+	Name     string `mapstructure:"name"`
+}
+
+// JWTConfig holds token settings.
+type JWTConfig struct {
+	Secret      string `mapstructure:"secret" secret:"true"`
+	ExpireHours int    `mapstructure:"expire_hours"`
+}
+
+// LogConfig holds logging settings.
+type LogConfig struct {
+	Level string `mapstructure:"level"`
+}
+
+func main() {
+	configPath := flag.String("config", "config.yaml", "config file (yaml, json or toml)")
+	flag.Parse()
+
+	loaded, err := NewConfigFromPath(*configPath)
+	if err != nil {
+		log.Fatalf("load config: %v", err)
+	}
+	config := *loaded
+
+	r := gin.Default()
+	r.Use(CORSFromConfig(config.CORS))
+
+	r.GET("/config", func(c *gin.Context) {
+		// Secrets must not leak through the debug endpoint.
+		c.JSON(http.StatusOK, config.Redacted())
+	})
+
+	addr := fmt.Sprintf("%s:%d", config.Server.Host, config.Server.Port)
+	if err := r.Run(addr); err != nil {
+		log.Fatalf("run: %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import "reflect"
+
+// mask replaces secret values in output.
+const mask = "****"
+
+// Redacted returns a copy of the config with every string field tagged
+// `secret:"true"` replaced by the mask, recursing into nested structs. New
+// secret fields only need the tag to be protected from logging.
+func (c *Config) Redacted() Config {
+	out := *c
+	redactStruct(reflect.ValueOf(&out).Elem())
+	return out
+}
+
+func redactStruct(v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+		switch field.Kind() {
+		case reflect.Struct:
+			redactStruct(field)
+		case reflect.String:
+			if t.Field(i).Tag.Get("secret") == "true" && field.String() != "" {
+				field.SetString(mask)
+			}
+		}
+	}
+}
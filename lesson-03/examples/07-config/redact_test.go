@@ -0,0 +1,34 @@
+package main
+
+import "testing"
+
+func TestRedactedMasksTaggedFields(t *testing.T) {
+	cfg := Config{
+		Server:   ServerConfig{Host: "0.0.0.0", Port: 8080},
+		Database: DatabaseConfig{Host: "db", User: "app", Password: "hunter2"},
+		JWT:      JWTConfig{Secret: "topsecret", ExpireHours: 24},
+	}
+
+	red := cfg.Redacted()
+	if red.Database.Password != mask {
+		t.Errorf("database password = %q, want masked", red.Database.Password)
+	}
+	if red.JWT.Secret != mask {
+		t.Errorf("jwt secret = %q, want masked", red.JWT.Secret)
+	}
+	if red.Database.Host != "db" || red.Database.User != "app" || red.Server.Port != 8080 {
+		t.Errorf("non-secret fields changed: %+v", red)
+	}
+
+	// The original must be untouched.
+	if cfg.Database.Password != "hunter2" || cfg.JWT.Secret != "topsecret" {
+		t.Errorf("Redacted mutated the receiver: %+v", cfg)
+	}
+}
+
+func TestRedactedLeavesEmptySecrets(t *testing.T) {
+	cfg := Config{}
+	if red := cfg.Redacted(); red.JWT.Secret != "" {
+		t.Errorf("empty secret = %q, want empty", red.JWT.Secret)
+	}
+}
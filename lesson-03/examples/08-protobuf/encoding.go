@@ -0,0 +1,47 @@
+package main
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/proto"
+)
+
+// gzipMinSize is the smallest payload worth compressing; below it the gzip
+// framing costs more than it saves.
+const gzipMinSize = 256
+
+// acceptsGzip reports whether the client advertised gzip support.
+func acceptsGzip(c *gin.Context) bool {
+	for _, enc := range strings.Split(c.GetHeader("Accept-Encoding"), ",") {
+		if strings.TrimSpace(strings.SplitN(enc, ";", 2)[0]) == "gzip" {
+			return true
+		}
+	}
+	return false
+}
+
+// writeProto marshals m and writes it as application/x-protobuf, gzipping
+// when the client accepts it and the payload clears gzipMinSize. Replaces
+// c.ProtoBuf so all proto endpoints share the negotiation.
+func writeProto(c *gin.Context, status int, m proto.Message) {
+	data, err := proto.Marshal(m)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "marshal response"})
+		return
+	}
+
+	if len(data) >= gzipMinSize && acceptsGzip(c) {
+		c.Header("Content-Type", "application/x-protobuf")
+		c.Header("Content-Encoding", "gzip")
+		c.Status(status)
+		gz := gzip.NewWriter(c.Writer)
+		if _, err := gz.Write(data); err == nil {
+			gz.Close()
+		}
+		return
+	}
+	c.Data(status, "application/x-protobuf", data)
+}
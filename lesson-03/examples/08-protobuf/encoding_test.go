@@ -0,0 +1,107 @@
+package main
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/lannisite110/hello_world/lesson-03/examples/08-protobuf/pb"
+)
+
+// bigUserStore returns a store whose full list comfortably clears
+// gzipMinSize once serialized.
+func bigUserStore() *userStore {
+	s := &userStore{nextID: 1}
+	for i := 0; i < 50; i++ {
+		s.users = append(s.users, &pb.User{
+			Id:     s.nextID,
+			Name:   fmt.Sprintf("User %02d", i),
+			Email:  fmt.Sprintf("user%02d@example.com", i),
+			Age:    int32(20 + i%40),
+			Active: true,
+		})
+		s.nextID++
+	}
+	return s
+}
+
+func TestGzipClientGetsCompressedList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := buildRouter(bigUserStore())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/proto/users?page_size=50", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d", w.Code)
+	}
+	if got := w.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("Content-Encoding = %q, want gzip", got)
+	}
+
+	gz, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("gzip reader: %v", err)
+	}
+	raw, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("decompress: %v", err)
+	}
+	var list pb.UserList
+	if err := proto.Unmarshal(raw, &list); err != nil {
+		t.Fatalf("unmarshal decompressed body: %v", err)
+	}
+	if len(list.Users) != 50 {
+		t.Fatalf("decoded %d users, want 50", len(list.Users))
+	}
+}
+
+func TestPlainClientGetsUncompressedList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := buildRouter(bigUserStore())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/proto/users?page_size=50", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Fatalf("Content-Encoding = %q, want none", got)
+	}
+	var list pb.UserList
+	if err := proto.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("unmarshal plain body: %v", err)
+	}
+	if len(list.Users) != 50 {
+		t.Fatalf("decoded %d users, want 50", len(list.Users))
+	}
+}
+
+func TestSmallResponseStaysUncompressed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := buildRouter(newUserStore())
+
+	// A single user is well under gzipMinSize even for a gzip client.
+	req := httptest.NewRequest(http.MethodGet, "/api/proto/user/1", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Fatalf("Content-Encoding = %q, want none below the threshold", got)
+	}
+	var user pb.User
+	if err := proto.Unmarshal(w.Body.Bytes(), &user); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if user.Name != "Alice" {
+		t.Fatalf("user = %+v", &user)
+	}
+}
@@ -0,0 +1,6 @@
+#!/bin/sh
+# Regenerate pb/ from proto/user.proto (requires buf and protoc-gen-go on PATH).
+set -e
+buf generate --path proto --template buf.gen.yaml .
+mv pb/proto/*.go pb/
+rmdir pb/proto
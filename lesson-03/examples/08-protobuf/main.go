@@ -0,0 +1,174 @@
+// Lesson 03-08: serving protobuf payloads over HTTP with Gin.
+//
+//	go run .
+//	curl -s localhost:8080/api/proto/users | protoc --decode_raw
+package main
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/lannisite110/hello_world/lesson-03/examples/08-protobuf/pb"
+	"github.com/lannisite110/hello_world/lesson-03/ginmw"
+)
+
+// userStore holds the demo data behind the proto endpoints.
+type userStore struct {
+	mu     sync.RWMutex
+	users  []*pb.User
+	nextID int64
+}
+
+func newUserStore() *userStore {
+	return &userStore{
+		users: []*pb.User{
+			{Id: 1, Name: "Alice", Email: "alice@example.com", Age: 30, Active: true,
+				Tags: []string{"admin", "golang"}, Metadata: map[string]string{"team": "core"}},
+			{Id: 2, Name: "Bob", Email: "bob@example.com", Age: 25, Active: true,
+				Tags: []string{"backend"}, Metadata: map[string]string{"team": "infra"}},
+			{Id: 3, Name: "Carol", Email: "carol@example.com", Age: 35, Active: false,
+				Tags: []string{"frontend"}, Metadata: map[string]string{}},
+		},
+		nextID: 4,
+	}
+}
+
+// getUserProto returns one user as a binary protobuf message.
+func (s *userStore) getUserProto(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, u := range s.users {
+		if u.Id == id {
+			writeProto(c, http.StatusOK, u)
+			return
+		}
+	}
+	c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+}
+
+// hasTag reports whether u carries the given tag.
+func hasTag(u *pb.User, tag string) bool {
+	for _, t := range u.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// getUserListProto returns the paged user list as protobuf. Optional
+// ?active=true/false and ?tag= query params narrow the list before paging,
+// so total always counts the filtered set.
+func (s *userStore) getUserListProto(c *gin.Context) {
+	page, size := ginmw.ParsePagination(c)
+
+	var activeFilter *bool
+	if raw := c.Query("active"); raw != "" {
+		active, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid active filter"})
+			return
+		}
+		activeFilter = &active
+	}
+	tag := c.Query("tag")
+
+	s.mu.RLock()
+	filtered := make([]*pb.User, 0, len(s.users))
+	for _, u := range s.users {
+		if activeFilter != nil && u.Active != *activeFilter {
+			continue
+		}
+		if tag != "" && !hasTag(u, tag) {
+			continue
+		}
+		filtered = append(filtered, u)
+	}
+	s.mu.RUnlock()
+
+	total := len(filtered)
+	start := (page - 1) * size
+	if start > total {
+		start = total
+	}
+	end := start + size
+	if end > total {
+		end = total
+	}
+
+	writeProto(c, http.StatusOK, &pb.UserList{
+		Users:    filtered[start:end],
+		Total:    int32(total),
+		Page:     int32(page),
+		PageSize: int32(size),
+	})
+}
+
+// createUserProto accepts a protobuf-encoded (or JSON) User body and stores
+// it with a fresh ID.
+func (s *userStore) createUserProto(c *gin.Context) {
+	var user pb.User
+	switch c.ContentType() {
+	case "application/json":
+		if err := c.ShouldBindJSON(&user); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	default:
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "read body"})
+			return
+		}
+		if err := proto.Unmarshal(body, &user); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid protobuf body"})
+			return
+		}
+	}
+	if user.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+		return
+	}
+
+	s.mu.Lock()
+	user.Id = s.nextID
+	s.nextID++
+	s.users = append(s.users, &user)
+	s.mu.Unlock()
+
+	writeProto(c, http.StatusCreated, &user)
+}
+
+// buildRouter wires the proto endpoints; extracted so tests can drive it with
+// httptest.
+func buildRouter(store *userStore) *gin.Engine {
+	r := gin.Default()
+	api := r.Group("/api/proto")
+	{
+		api.GET("/schema", getSchema)
+		api.GET("/user/:id", store.getUserProto)
+		api.GET("/users", store.getUserListProto)
+		api.POST("/user",
+			ginmw.RequireContentType("application/x-protobuf", "application/json"),
+			store.createUserProto)
+	}
+	return r
+}
+
+func main() {
+	r := buildRouter(newUserStore())
+	if err := r.Run(":8080"); err != nil {
+		log.Fatalf("run: %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/lannisite110/hello_world/lesson-03/examples/08-protobuf/pb"
+)
+
+// listUsers GETs /api/proto/users with the given query and decodes the
+// protobuf body.
+func listUsers(t *testing.T, r *gin.Engine, query string) *pb.UserList {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/proto/users"+query, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+	}
+	var list pb.UserList
+	if err := proto.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	return &list
+}
+
+func TestListUsersActiveFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := buildRouter(newUserStore())
+
+	list := listUsers(t, r, "?active=true")
+	if list.Total != 2 || len(list.Users) != 2 {
+		t.Fatalf("active=true: total=%d users=%d, want 2/2", list.Total, len(list.Users))
+	}
+	for _, u := range list.Users {
+		if !u.Active {
+			t.Fatalf("inactive user in active-only list: %+v", u)
+		}
+	}
+
+	inactive := listUsers(t, r, "?active=false")
+	if inactive.Total != 1 || inactive.Users[0].Name != "Carol" {
+		t.Fatalf("active=false: total=%d, got %+v", inactive.Total, inactive.Users)
+	}
+}
+
+func TestListUsersTagFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := buildRouter(newUserStore())
+
+	list := listUsers(t, r, "?tag=golang")
+	if list.Total != 1 || len(list.Users) != 1 {
+		t.Fatalf("tag=golang: total=%d users=%d, want 1/1", list.Total, len(list.Users))
+	}
+	if list.Users[0].Name != "Alice" {
+		t.Fatalf("tag=golang matched %q, want Alice", list.Users[0].Name)
+	}
+
+	if none := listUsers(t, r, "?tag=devops"); none.Total != 0 || len(none.Users) != 0 {
+		t.Fatalf("tag=devops: total=%d users=%d, want empty", none.Total, len(none.Users))
+	}
+}
+
+func TestListUsersBadActiveFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := buildRouter(newUserStore())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/proto/users?active=maybe", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400 for a bad bool", w.Code)
+	}
+}
+
+func TestListUsersFilterThenPaginate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := buildRouter(newUserStore())
+
+	// Two active users, one per page: total counts the filtered set, not
+	// the whole store.
+	list := listUsers(t, r, "?active=true&page=2&page_size=1")
+	if list.Total != 2 || len(list.Users) != 1 {
+		t.Fatalf("page 2: total=%d users=%d, want 2/1", list.Total, len(list.Users))
+	}
+	if list.Users[0].Name != "Bob" {
+		t.Fatalf("page 2 of active users = %q, want Bob", list.Users[0].Name)
+	}
+}
@@ -0,0 +1,70 @@
+// Package models bridges the protobuf User and its GORM representation.
+// Tags and metadata, which have no natural column type, are stored as JSON
+// text columns.
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/lannisite110/hello_world/lesson-03/examples/08-protobuf/pb"
+)
+
+// User is the GORM-side user row.
+type User struct {
+	ID       int64  `gorm:"primarykey"`
+	Name     string `gorm:"size:64"`
+	Email    string `gorm:"size:128"`
+	Age      int32
+	Active   bool
+	Tags     string `gorm:"type:text"` // JSON array of strings
+	Metadata string `gorm:"type:text"` // JSON object of string->string
+}
+
+// PBToUser converts a protobuf user into the GORM model, serializing tags
+// and metadata to JSON columns.
+func PBToUser(in *pb.User) (User, error) {
+	tags, err := json.Marshal(in.GetTags())
+	if err != nil {
+		return User{}, fmt.Errorf("marshal tags: %w", err)
+	}
+	meta := in.GetMetadata()
+	if meta == nil {
+		meta = map[string]string{}
+	}
+	metaJSON, err := json.Marshal(meta)
+	if err != nil {
+		return User{}, fmt.Errorf("marshal metadata: %w", err)
+	}
+	return User{
+		ID:       in.GetId(),
+		Name:     in.GetName(),
+		Email:    in.GetEmail(),
+		Age:      in.GetAge(),
+		Active:   in.GetActive(),
+		Tags:     string(tags),
+		Metadata: string(metaJSON),
+	}, nil
+}
+
+// UserToPB converts the GORM model back into its protobuf form.
+func UserToPB(in User) (*pb.User, error) {
+	out := &pb.User{
+		Id:     in.ID,
+		Name:   in.Name,
+		Email:  in.Email,
+		Age:    in.Age,
+		Active: in.Active,
+	}
+	if in.Tags != "" {
+		if err := json.Unmarshal([]byte(in.Tags), &out.Tags); err != nil {
+			return nil, fmt.Errorf("unmarshal tags: %w", err)
+		}
+	}
+	if in.Metadata != "" {
+		if err := json.Unmarshal([]byte(in.Metadata), &out.Metadata); err != nil {
+			return nil, fmt.Errorf("unmarshal metadata: %w", err)
+		}
+	}
+	return out, nil
+}
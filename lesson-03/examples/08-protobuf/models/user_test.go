@@ -0,0 +1,60 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lannisite110/hello_world/lesson-03/examples/08-protobuf/pb"
+)
+
+func TestPBUserRoundTrip(t *testing.T) {
+	in := &pb.User{
+		Id:     42,
+		Name:   "Alice",
+		Email:  "alice@example.com",
+		Age:    30,
+		Active: true,
+		Tags:   []string{"admin", "golang"},
+		Metadata: map[string]string{
+			"team":   "core",
+			"office": "berlin",
+		},
+	}
+
+	model, err := PBToUser(in)
+	if err != nil {
+		t.Fatalf("PBToUser: %v", err)
+	}
+	if model.ID != 42 || model.Name != "Alice" || model.Age != 30 || !model.Active {
+		t.Fatalf("model = %+v", model)
+	}
+
+	back, err := UserToPB(model)
+	if err != nil {
+		t.Fatalf("UserToPB: %v", err)
+	}
+	if back.Id != in.Id || back.Name != in.Name || back.Email != in.Email ||
+		back.Age != in.Age || back.Active != in.Active {
+		t.Fatalf("scalar fields differ: %+v vs %+v", back, in)
+	}
+	if !reflect.DeepEqual(back.Tags, in.Tags) {
+		t.Fatalf("tags = %v, want %v", back.Tags, in.Tags)
+	}
+	if !reflect.DeepEqual(back.Metadata, in.Metadata) {
+		t.Fatalf("metadata = %v, want %v", back.Metadata, in.Metadata)
+	}
+}
+
+func TestPBUserRoundTripEmptyCollections(t *testing.T) {
+	model, err := PBToUser(&pb.User{Id: 1, Name: "Bob"})
+	if err != nil {
+		t.Fatalf("PBToUser: %v", err)
+	}
+	back, err := UserToPB(model)
+	if err != nil {
+		t.Fatalf("UserToPB: %v", err)
+	}
+	if len(back.Tags) != 0 || len(back.Metadata) != 0 {
+		t.Fatalf("expected empty collections, got tags=%v metadata=%v", back.Tags, back.Metadata)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/lannisite110/hello_world/lesson-03/examples/08-protobuf/pb"
+)
+
+// fieldSchema describes one field of a message for clients inspecting the
+// contract.
+type fieldSchema struct {
+	Name     string `json:"name"`
+	Number   int32  `json:"number"`
+	Kind     string `json:"kind"`
+	Repeated bool   `json:"repeated,omitempty"`
+	Map      bool   `json:"map,omitempty"`
+}
+
+// messageSchema is one message with its fields.
+type messageSchema struct {
+	Name   string        `json:"name"`
+	Fields []fieldSchema `json:"fields"`
+}
+
+// describeMessages walks a file descriptor into the JSON-friendly schema
+// shape. Kept separate from the handler so it is unit-testable without HTTP.
+func describeMessages(fd protoreflect.FileDescriptor) []messageSchema {
+	msgs := fd.Messages()
+	out := make([]messageSchema, 0, msgs.Len())
+	for i := 0; i < msgs.Len(); i++ {
+		md := msgs.Get(i)
+		fields := md.Fields()
+		ms := messageSchema{
+			Name:   string(md.Name()),
+			Fields: make([]fieldSchema, 0, fields.Len()),
+		}
+		for j := 0; j < fields.Len(); j++ {
+			f := fields.Get(j)
+			ms.Fields = append(ms.Fields, fieldSchema{
+				Name:     string(f.Name()),
+				Number:   int32(f.Number()),
+				Kind:     f.Kind().String(),
+				Repeated: f.IsList(),
+				Map:      f.IsMap(),
+			})
+		}
+		out = append(out, ms)
+	}
+	return out
+}
+
+// getSchema serves the message schema of the pb package so clients can
+// discover the contract without the .proto source.
+func getSchema(c *gin.Context) {
+	fd := pb.File_proto_user_proto
+	c.JSON(http.StatusOK, gin.H{
+		"file":     fd.Path(),
+		"package":  string(fd.Package()),
+		"messages": describeMessages(fd),
+	})
+}
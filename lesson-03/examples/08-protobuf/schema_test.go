@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSchemaListsUserMessage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := buildRouter(newUserStore())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/proto/schema", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Package  string          `json:"package"`
+		Messages []messageSchema `json:"messages"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if resp.Package != "userpb" {
+		t.Fatalf("package = %q, want userpb", resp.Package)
+	}
+
+	var user *messageSchema
+	for i := range resp.Messages {
+		if resp.Messages[i].Name == "User" {
+			user = &resp.Messages[i]
+		}
+	}
+	if user == nil {
+		t.Fatalf("User message missing from schema: %+v", resp.Messages)
+	}
+
+	fields := map[string]fieldSchema{}
+	for _, f := range user.Fields {
+		fields[f.Name] = f
+	}
+	for _, name := range []string{"id", "name", "email", "age", "active", "tags", "metadata"} {
+		if _, ok := fields[name]; !ok {
+			t.Fatalf("User field %q missing: %+v", name, user.Fields)
+		}
+	}
+	if !fields["tags"].Repeated {
+		t.Fatal("tags must be reported as repeated")
+	}
+	if !fields["metadata"].Map {
+		t.Fatal("metadata must be reported as a map")
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/lannisite110/hello_world/lesson-03/examples/09-grpc/pb"
+)
+
+// startBufconnClient runs the full gRPC server on an in-memory listener and
+// returns a client connected to it. No TCP port is opened, so these tests are
+// fast and safe to run in parallel with anything else on the machine.
+func startBufconnClient(t *testing.T, opts ...grpc.ServerOption) pb.UserServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := newGRPCServer(false, opts...)
+	go func() {
+		if err := srv.Serve(lis); err != nil {
+			t.Logf("serve: %v", err)
+		}
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial bufconn: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewUserServiceClient(conn)
+}
+
+func TestBufconnGetUser(t *testing.T) {
+	client := startBufconnClient(t)
+	ctx := context.Background()
+
+	user, err := client.GetUser(ctx, &pb.GetUserRequest{Id: 1})
+	if err != nil {
+		t.Fatalf("get seeded user: %v", err)
+	}
+	if user.Name != "Alice" {
+		t.Fatalf("user 1 = %q, want Alice", user.Name)
+	}
+
+	if _, err := client.GetUser(ctx, &pb.GetUserRequest{Id: 999}); status.Code(err) != codes.NotFound {
+		t.Fatalf("missing user: err = %v, want NotFound", err)
+	}
+}
+
+func TestBufconnCreateUserRoundTrip(t *testing.T) {
+	client := startBufconnClient(t)
+	ctx := context.Background()
+
+	created, err := client.CreateUser(ctx, &pb.CreateUserRequest{
+		Name: "Dave", Email: "dave@example.com", Age: 28,
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	got, err := client.GetUser(ctx, &pb.GetUserRequest{Id: created.Id})
+	if err != nil {
+		t.Fatalf("get created user: %v", err)
+	}
+	if got.Name != "Dave" || got.Email != "dave@example.com" || !got.Active {
+		t.Fatalf("round-tripped user = %+v", got)
+	}
+}
+
+func TestBufconnStreamUsers(t *testing.T) {
+	client := startBufconnClient(t)
+	ctx := context.Background()
+
+	stream, err := client.StreamUsers(ctx, &pb.StreamUsersRequest{Limit: 2})
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+
+	var ids []int64
+	for {
+		user, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("recv: %v", err)
+		}
+		ids = append(ids, user.Id)
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Fatalf("streamed ids = %v, want [1 2]", ids)
+	}
+}
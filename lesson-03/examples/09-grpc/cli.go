@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+)
+
+// serverOptions are the flags of the server subcommand.
+type serverOptions struct {
+	addr       string
+	tls        bool
+	reflection bool
+}
+
+// clientOptions are the flags of the client subcommand.
+type clientOptions struct {
+	addr  string
+	id    int64
+	token string
+}
+
+// command is one parsed invocation: exactly one of server/client is set.
+type command struct {
+	name   string
+	server serverOptions
+	client clientOptions
+}
+
+// usage lists the subcommands and their flags.
+func usage(w io.Writer) {
+	fmt.Fprintln(w, `usage: 09-grpc <command> [flags]
+
+commands:
+  server    run the gRPC user service
+              -addr        listen address (default localhost:50051)
+              -tls         serve with TLS (requires TLS_CERT/TLS_KEY env)
+              -reflection  serve gRPC reflection (debug only)
+  client    run the demo client against a server
+              -addr        server address (default localhost:50051)
+              -id          user id the demo fetches (default 0: use the created user)
+              -token       bearer token sent as authorization metadata`)
+}
+
+// parseCommand parses args (without the program name) into a command. A
+// missing or unknown subcommand prints the usage to stderr and errors.
+func parseCommand(args []string, stderr io.Writer) (*command, error) {
+	if len(args) == 0 {
+		usage(stderr)
+		return nil, fmt.Errorf("missing command")
+	}
+
+	switch args[0] {
+	case "server":
+		fs := flag.NewFlagSet("server", flag.ContinueOnError)
+		fs.SetOutput(stderr)
+		cmd := &command{name: "server"}
+		fs.StringVar(&cmd.server.addr, "addr", "localhost:50051", "listen address")
+		fs.BoolVar(&cmd.server.tls, "tls", false, "serve with TLS")
+		fs.BoolVar(&cmd.server.reflection, "reflection", false, "serve gRPC reflection")
+		if err := fs.Parse(args[1:]); err != nil {
+			return nil, err
+		}
+		return cmd, nil
+	case "client":
+		fs := flag.NewFlagSet("client", flag.ContinueOnError)
+		fs.SetOutput(stderr)
+		cmd := &command{name: "client"}
+		fs.StringVar(&cmd.client.addr, "addr", "localhost:50051", "server address")
+		fs.Int64Var(&cmd.client.id, "id", 0, "user id to fetch")
+		fs.StringVar(&cmd.client.token, "token", "", "bearer token")
+		if err := fs.Parse(args[1:]); err != nil {
+			return nil, err
+		}
+		return cmd, nil
+	default:
+		usage(stderr)
+		return nil, fmt.Errorf("unknown command %q", args[0])
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseCommandMissingPrintsUsage(t *testing.T) {
+	var stderr bytes.Buffer
+	_, err := parseCommand(nil, &stderr)
+	if err == nil {
+		t.Fatal("missing command must error")
+	}
+	if !strings.Contains(stderr.String(), "commands:") {
+		t.Fatalf("usage not printed:\n%s", stderr.String())
+	}
+}
+
+func TestParseCommandUnknownPrintsUsage(t *testing.T) {
+	var stderr bytes.Buffer
+	_, err := parseCommand([]string{"proxy"}, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "proxy") {
+		t.Fatalf("err = %v, want unknown command mentioning it", err)
+	}
+	if !strings.Contains(stderr.String(), "commands:") {
+		t.Fatalf("usage not printed:\n%s", stderr.String())
+	}
+}
+
+func TestParseCommandServerFlags(t *testing.T) {
+	var stderr bytes.Buffer
+	cmd, err := parseCommand([]string{"server", "-addr", ":6000", "-reflection", "-tls"}, &stderr)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if cmd.name != "server" {
+		t.Fatalf("name = %q, want server", cmd.name)
+	}
+	if cmd.server.addr != ":6000" || !cmd.server.reflection || !cmd.server.tls {
+		t.Fatalf("server options = %+v", cmd.server)
+	}
+}
+
+func TestParseCommandClientFlags(t *testing.T) {
+	var stderr bytes.Buffer
+	cmd, err := parseCommand([]string{"client", "-id", "7", "-token", "secret"}, &stderr)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if cmd.name != "client" {
+		t.Fatalf("name = %q, want client", cmd.name)
+	}
+	if cmd.client.addr != "localhost:50051" || cmd.client.id != 7 || cmd.client.token != "secret" {
+		t.Fatalf("client options = %+v", cmd.client)
+	}
+}
+
+func TestParseCommandBadFlag(t *testing.T) {
+	var stderr bytes.Buffer
+	if _, err := parseCommand([]string{"client", "-nope"}, &stderr); err == nil {
+		t.Fatal("undefined flag must error")
+	}
+}
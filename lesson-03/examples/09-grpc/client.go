@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+
+	"github.com/lannisite110/hello_world/lesson-03/examples/09-grpc/pb"
+)
+
+// defaultDialTimeout bounds the initial connection attempt when none is
+// given.
+const defaultDialTimeout = 5 * time.Second
+
+// UserServiceClient wraps the generated client with a managed connection.
+type UserServiceClient struct {
+	conn   *grpc.ClientConn
+	client pb.UserServiceClient
+	health grpc_health_v1.HealthClient
+}
+
+// NewUserServiceClient dials addr without TLS (this is a local demo) and
+// blocks until connected or dialTimeout elapses (<= 0 uses the default).
+// Keepalive pings keep long-lived idle connections from being dropped by
+// intermediaries.
+func NewUserServiceClient(addr string, dialTimeout time.Duration) (*UserServiceClient, error) {
+	if dialTimeout <= 0 {
+		dialTimeout = defaultDialTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                30 * time.Second,
+			Timeout:             10 * time.Second,
+			PermitWithoutStream: true,
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("dial %s: %w", addr, err)
+	}
+	return &UserServiceClient{
+		conn:   conn,
+		client: pb.NewUserServiceClient(conn),
+		health: grpc_health_v1.NewHealthClient(conn),
+	}, nil
+}
+
+// Ping checks connectivity via the standard health service, failing when the
+// server is unreachable or not SERVING.
+func (c *UserServiceClient) Ping(ctx context.Context) error {
+	resp, err := c.health.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		return fmt.Errorf("health check: %w", err)
+	}
+	if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		return fmt.Errorf("server not serving: %s", resp.Status)
+	}
+	return nil
+}
+
+// Close tears down the underlying connection.
+func (c *UserServiceClient) Close() error { return c.conn.Close() }
+
+// readyMaxDelay caps the backoff between readiness probes.
+const readyMaxDelay = 2 * time.Second
+
+// waitForReady polls check with doubling backoff until it succeeds or ctx
+// expires. It exists so the demo survives the server and client starting
+// roughly together: the first probes fail while the server is still binding
+// its listener. Extracted from the client wrapper so tests can drive it with
+// a scripted check.
+func waitForReady(ctx context.Context, check func(context.Context) error, base time.Duration) error {
+	if base <= 0 {
+		base = 100 * time.Millisecond
+	}
+	delay := base
+	var lastErr error
+	for {
+		if lastErr = check(ctx); lastErr == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("server not ready: %w (last error: %v)", ctx.Err(), lastErr)
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > readyMaxDelay {
+			delay = readyMaxDelay
+		}
+	}
+}
+
+// WaitReady blocks until the health service reports SERVING, probing with
+// backoff starting at base (<= 0 uses a sensible default). The ctx deadline
+// bounds the total wait.
+func (c *UserServiceClient) WaitReady(ctx context.Context, base time.Duration) error {
+	return waitForReady(ctx, c.Ping, base)
+}
+
+// runClientDemo exercises every RPC once, logging each call with structured
+// fields. Failures are collected and returned joined so the caller can tell
+// the demo didn't fully succeed.
+func runClientDemo(ctx context.Context, client pb.UserServiceClient, logger *slog.Logger) error {
+	var failures []error
+
+	call := func(method string, fn func() (any, error)) {
+		start := time.Now()
+		result, err := fn()
+		duration := time.Since(start)
+		if err != nil {
+			logger.Error("rpc failed", "method", method, "duration", duration, "err", err)
+			failures = append(failures, fmt.Errorf("%s: %w", method, err))
+			return
+		}
+		logger.Info("rpc ok", "method", method, "duration", duration, "result", result)
+	}
+
+	var created *pb.User
+	call("CreateUser", func() (any, error) {
+		u, err := client.CreateUser(ctx, &pb.CreateUserRequest{
+			Name: "Dave", Email: "dave@example.com", Age: 28,
+		})
+		created = u
+		return u, err
+	})
+
+	if created != nil {
+		call("GetUser", func() (any, error) {
+			return client.GetUser(ctx, &pb.GetUserRequest{Id: created.Id})
+		})
+		call("UpdateUser", func() (any, error) {
+			return client.UpdateUser(ctx, &pb.UpdateUserRequest{
+				Id: created.Id, Email: "dave@example.org", Active: true,
+			})
+		})
+	}
+
+	call("ListUsers", func() (any, error) {
+		resp, err := client.ListUsers(ctx, &pb.ListUsersRequest{Page: 1, PageSize: 10})
+		if err != nil {
+			return nil, err
+		}
+		return fmt.Sprintf("%d users (total %d, page %d/%d, has_next=%v)",
+			len(resp.Users), resp.Total, resp.Page, resp.TotalPages, resp.HasNext), nil
+	})
+
+	call("StreamUsers", func() (any, error) {
+		stream, err := client.StreamUsers(ctx, &pb.StreamUsersRequest{Limit: 5, IntervalMs: 10})
+		if err != nil {
+			return nil, err
+		}
+		count := 0
+		for {
+			user, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			logger.Info("stream user", "method", "StreamUsers", "id", user.Id, "name", user.Name)
+			count++
+		}
+		return fmt.Sprintf("%d streamed", count), nil
+	})
+
+	if created != nil {
+		call("DeleteUser", func() (any, error) {
+			return client.DeleteUser(ctx, &pb.DeleteUserRequest{Id: created.Id})
+		})
+	}
+
+	return errors.Join(failures...)
+}
+
+// streamUsersCallback consumes a StreamUsers stream, invoking fn for every
+// received user. A callback error stops consumption early and cancels the
+// stream so the server stops producing. Extracted from the client wrapper so
+// tests can drive it with a fake client.
+func streamUsersCallback(ctx context.Context, client pb.UserServiceClient, limit, intervalMs int32, fn func(*pb.User) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := client.StreamUsers(ctx, &pb.StreamUsersRequest{Limit: limit, IntervalMs: intervalMs})
+	if err != nil {
+		return fmt.Errorf("stream users: %w", err)
+	}
+	for {
+		user, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("recv: %w", err)
+		}
+		if err := fn(user); err != nil {
+			return fmt.Errorf("callback: %w", err)
+		}
+	}
+}
+
+// StreamUsersCallback streams up to limit users, invoking fn per user; see
+// streamUsersCallback for the early-stop semantics.
+func (c *UserServiceClient) StreamUsersCallback(ctx context.Context, limit, intervalMs int32, fn func(*pb.User) error) error {
+	return streamUsersCallback(ctx, c.client, limit, intervalMs, fn)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/lannisite110/hello_world/lesson-03/examples/09-grpc/pb"
+)
+
+// fakeUserClient answers every RPC from memory; GetUser fails when failGet is
+// set so tests can check error aggregation.
+type fakeUserClient struct {
+	failGet bool
+}
+
+func (f *fakeUserClient) GetUser(ctx context.Context, req *pb.GetUserRequest, opts ...grpc.CallOption) (*pb.User, error) {
+	if f.failGet {
+		return nil, status.Error(codes.Unavailable, "injected failure")
+	}
+	return &pb.User{Id: req.Id, Name: "Test"}, nil
+}
+
+func (f *fakeUserClient) CreateUser(ctx context.Context, req *pb.CreateUserRequest, opts ...grpc.CallOption) (*pb.User, error) {
+	return &pb.User{Id: 1, Name: req.Name, Email: req.Email, Age: req.Age, Active: true}, nil
+}
+
+func (f *fakeUserClient) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest, opts ...grpc.CallOption) (*pb.User, error) {
+	return &pb.User{Id: req.Id, Name: req.Name, Email: req.Email, Age: req.Age, Active: req.Active}, nil
+}
+
+func (f *fakeUserClient) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest, opts ...grpc.CallOption) (*pb.DeleteUserResponse, error) {
+	return &pb.DeleteUserResponse{Success: true}, nil
+}
+
+func (f *fakeUserClient) RestoreUser(ctx context.Context, req *pb.RestoreUserRequest, opts ...grpc.CallOption) (*pb.User, error) {
+	return &pb.User{Id: req.Id, Name: "Test"}, nil
+}
+
+func (f *fakeUserClient) ListUsers(ctx context.Context, req *pb.ListUsersRequest, opts ...grpc.CallOption) (*pb.ListUsersResponse, error) {
+	return &pb.ListUsersResponse{Total: 0, Page: req.Page, PageSize: req.PageSize}, nil
+}
+
+func (f *fakeUserClient) StreamUsers(ctx context.Context, req *pb.StreamUsersRequest, opts ...grpc.CallOption) (pb.UserService_StreamUsersClient, error) {
+	return &fakeUserStream{}, nil
+}
+
+type fakeUserStream struct {
+	grpc.ClientStream
+}
+
+func (s *fakeUserStream) Recv() (*pb.User, error) { return nil, io.EOF }
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestRunClientDemoAllOK(t *testing.T) {
+	err := runClientDemo(context.Background(), &fakeUserClient{}, discardLogger())
+	if err != nil {
+		t.Fatalf("runClientDemo: %v", err)
+	}
+}
+
+func TestRunClientDemoAggregatesFailure(t *testing.T) {
+	err := runClientDemo(context.Background(), &fakeUserClient{failGet: true}, discardLogger())
+	if err == nil {
+		t.Fatal("runClientDemo returned nil, want aggregated error from failed GetUser")
+	}
+	if status.Code(err) == codes.OK {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
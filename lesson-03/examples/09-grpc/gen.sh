@@ -0,0 +1,7 @@
+#!/bin/sh
+# Regenerate pb/ from proto/user.proto (requires buf, protoc-gen-go,
+# protoc-gen-go-grpc on PATH).
+set -e
+buf generate --path proto --template buf.gen.yaml .
+mv pb/proto/*.go pb/
+rmdir pb/proto
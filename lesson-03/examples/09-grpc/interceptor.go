@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultHandlerTimeout applies when the client set no deadline at all,
+	// so a forgotten deadline cannot pin a handler forever.
+	defaultHandlerTimeout = 10 * time.Second
+	// slowHandlerThreshold is how long a handler may run before it is logged
+	// as slow.
+	slowHandlerThreshold = 500 * time.Millisecond
+)
+
+// timeoutInterceptor returns a unary interceptor that enforces timeout on
+// requests carrying no deadline and logs handlers slower than slow via logf.
+// The handler runs in its own goroutine so a handler that ignores its context
+// still cannot hold the RPC open past the deadline; the client gets
+// DeadlineExceeded and the stray goroutine finishes into the void.
+func timeoutInterceptor(timeout, slow time.Duration, logf func(format string, args ...any)) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		type result struct {
+			resp any
+			err  error
+		}
+		done := make(chan result, 1)
+		start := time.Now()
+		go func() {
+			resp, err := handler(ctx, req)
+			done <- result{resp, err}
+		}()
+
+		select {
+		case <-ctx.Done():
+			logf("deadline exceeded: %s after %s", info.FullMethod, time.Since(start))
+			return nil, status.FromContextError(ctx.Err()).Err()
+		case r := <-done:
+			if elapsed := time.Since(start); elapsed > slow {
+				logf("slow handler: %s took %s", info.FullMethod, elapsed)
+			}
+			return r.resp, r.err
+		}
+	}
+}
+
+// defaultTimeoutInterceptor is the production wiring of timeoutInterceptor.
+func defaultTimeoutInterceptor() grpc.UnaryServerInterceptor {
+	return timeoutInterceptor(defaultHandlerTimeout, slowHandlerThreshold, log.Printf)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/lannisite110/hello_world/lesson-03/examples/09-grpc/pb"
+)
+
+// slowUserServer answers GetUser only after sleeping, to exercise the
+// timeout interceptor end to end.
+type slowUserServer struct {
+	pb.UnimplementedUserServiceServer
+	sleep time.Duration
+}
+
+func (s *slowUserServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	time.Sleep(s.sleep)
+	return &pb.User{Id: req.Id, Name: "Sloth"}, nil
+}
+
+// testLogf collects interceptor log lines under a lock so the test can
+// inspect them after concurrent handlers finish.
+type testLogf struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *testLogf) logf(format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+func (l *testLogf) joined() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return strings.Join(l.lines, "\n")
+}
+
+func dialSlowServer(t *testing.T, sleep time.Duration, interceptor grpc.UnaryServerInterceptor) pb.UserServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptor))
+	pb.RegisterUserServiceServer(srv, &slowUserServer{sleep: sleep})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial bufconn: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewUserServiceClient(conn)
+}
+
+func TestTimeoutInterceptorAppliesDefaultDeadline(t *testing.T) {
+	logs := &testLogf{}
+	client := dialSlowServer(t, 200*time.Millisecond,
+		timeoutInterceptor(30*time.Millisecond, time.Second, logs.logf))
+
+	// No deadline on the client context: the server-side default must fire.
+	_, err := client.GetUser(context.Background(), &pb.GetUserRequest{Id: 1})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("err = %v, want DeadlineExceeded", err)
+	}
+	if !strings.Contains(logs.joined(), "deadline exceeded") {
+		t.Fatalf("missing deadline log:\n%s", logs.joined())
+	}
+}
+
+func TestTimeoutInterceptorLogsSlowHandler(t *testing.T) {
+	logs := &testLogf{}
+	client := dialSlowServer(t, 50*time.Millisecond,
+		timeoutInterceptor(time.Second, 10*time.Millisecond, logs.logf))
+
+	user, err := client.GetUser(context.Background(), &pb.GetUserRequest{Id: 1})
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if user.Name != "Sloth" {
+		t.Fatalf("user = %+v", user)
+	}
+	if !strings.Contains(logs.joined(), "slow handler") {
+		t.Fatalf("missing slow-handler log:\n%s", logs.joined())
+	}
+}
+
+func TestTimeoutInterceptorKeepsClientDeadline(t *testing.T) {
+	logs := &testLogf{}
+	// Server default is generous; the client's own tight deadline must win.
+	client := dialSlowServer(t, 200*time.Millisecond,
+		timeoutInterceptor(time.Minute, time.Second, logs.logf))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	_, err := client.GetUser(ctx, &pb.GetUserRequest{Id: 1})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("err = %v, want DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,78 @@
+// Lesson 03-09: a gRPC user service with unary and streaming RPCs.
+//
+//	go run . server
+//	go run . client
+package main
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/lannisite110/hello_world/lesson-03/examples/09-grpc/pb"
+)
+
+func main() {
+	cmd, err := parseCommand(os.Args[1:], os.Stderr)
+	if err != nil {
+		os.Exit(2)
+	}
+
+	switch cmd.name {
+	case "server":
+		enableReflection := cmd.server.reflection || os.Getenv("GRPC_REFLECTION") == "1"
+		var opts []grpc.ServerOption
+		if cmd.server.tls {
+			creds, err := credentials.NewServerTLSFromFile(os.Getenv("TLS_CERT"), os.Getenv("TLS_KEY"))
+			if err != nil {
+				log.Fatalf("load TLS credentials (TLS_CERT/TLS_KEY): %v", err)
+			}
+			opts = append(opts, grpc.Creds(creds))
+		}
+		if err := startServer(cmd.server.addr, enableReflection, opts...); err != nil {
+			log.Fatalf("server: %v", err)
+		}
+	case "client":
+		client, err := NewUserServiceClient(cmd.client.addr, 5*time.Second)
+		if err != nil {
+			log.Fatalf("client: %v", err)
+		}
+		defer client.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if cmd.client.token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+cmd.client.token)
+		}
+
+		logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+		// Wait out a server that is still starting rather than failing the
+		// first RPC.
+		waitCtx, cancelWait := context.WithTimeout(ctx, 10*time.Second)
+		err = client.WaitReady(waitCtx, 0)
+		cancelWait()
+		if err != nil {
+			log.Fatalf("wait for server: %v", err)
+		}
+
+		if cmd.client.id != 0 {
+			// With -id, fetch just that user instead of the full demo.
+			user, err := client.client.GetUser(ctx, &pb.GetUserRequest{Id: cmd.client.id})
+			if err != nil {
+				log.Fatalf("get user %d: %v", cmd.client.id, err)
+			}
+			logger.Info("GetUser", "user", user.String())
+			return
+		}
+		if err := runClientDemo(ctx, client.client, logger); err != nil {
+			log.Fatalf("client demo: %v", err)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitForReadySucceedsOnceServerComesUp(t *testing.T) {
+	attempts := 0
+	check := func(ctx context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := waitForReady(ctx, check, time.Millisecond); err != nil {
+		t.Fatalf("waitForReady: %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestWaitForReadyTimesOutWhenServerNeverUp(t *testing.T) {
+	check := func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := waitForReady(ctx, check, time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want DeadlineExceeded", err)
+	}
+	// The last probe failure must survive into the returned error so the
+	// operator can tell why the wait never succeeded.
+	if !strings.Contains(err.Error(), "connection refused") {
+		t.Fatalf("err = %v, missing last probe error", err)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+// serveOnce starts a fresh server on addr and returns a stop function.
+func serveOnce(t *testing.T, addr string) func() {
+	t.Helper()
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		t.Fatalf("listen %s: %v", addr, err)
+	}
+	srv := newGRPCServer(false)
+	go srv.Serve(lis)
+	return srv.Stop
+}
+
+func TestClientReconnectsAfterServerRestart(t *testing.T) {
+	// Reserve a port, then release it for the real server.
+	probe, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("probe listen: %v", err)
+	}
+	addr := probe.Addr().String()
+	probe.Close()
+
+	stop := serveOnce(t, addr)
+
+	client, err := NewUserServiceClient(addr, 5*time.Second)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx); err != nil {
+		t.Fatalf("initial ping: %v", err)
+	}
+
+	// Restart the server on the same port; the channel must recover.
+	stop()
+	stop = serveOnce(t, addr)
+	defer stop()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		pingCtx, pingCancel := context.WithTimeout(ctx, time.Second)
+		err = client.Ping(pingCtx)
+		pingCancel()
+		if err == nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("client never reconnected: %v", err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
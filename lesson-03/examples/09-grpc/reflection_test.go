@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+// listServices issues one reflection ListServices call against addr.
+func listServices(t *testing.T, addr string) error {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	if err != nil {
+		t.Fatalf("dial %s: %v", addr, err)
+	}
+	defer conn.Close()
+
+	stream, err := grpc_reflection_v1alpha.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return err
+	}
+	req := &grpc_reflection_v1alpha.ServerReflectionRequest{
+		MessageRequest: &grpc_reflection_v1alpha.ServerReflectionRequest_ListServices{ListServices: ""},
+	}
+	if err := stream.Send(req); err != nil {
+		return err
+	}
+	_, err = stream.Recv()
+	return err
+}
+
+func serveReflection(t *testing.T, enable bool) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	srv := newGRPCServer(enable)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	return lis.Addr().String()
+}
+
+func TestReflectionDisabledByDefault(t *testing.T) {
+	addr := serveReflection(t, false)
+	if err := listServices(t, addr); err == nil {
+		t.Fatal("reflection served although disabled")
+	}
+}
+
+func TestReflectionEnabled(t *testing.T) {
+	addr := serveReflection(t, true)
+	if err := listServices(t, addr); err != nil {
+		t.Fatalf("reflection call failed although enabled: %v", err)
+	}
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"github.com/lannisite110/hello_world/lesson-03/examples/09-grpc/pb"
+)
+
+// userServiceServer is an in-memory implementation of pb.UserServiceServer.
+type userServiceServer struct {
+	pb.UnimplementedUserServiceServer
+
+	mu     sync.RWMutex
+	users  map[int64]*pb.User
+	nextID int64
+}
+
+// newUserServiceServer returns a server pre-seeded with a few users.
+func newUserServiceServer() *userServiceServer {
+	s := &userServiceServer{
+		users:  make(map[int64]*pb.User),
+		nextID: 1,
+	}
+	for _, u := range []struct {
+		name, email string
+		age         int32
+	}{
+		{"Alice", "alice@example.com", 30},
+		{"Bob", "bob@example.com", 25},
+		{"Carol", "carol@example.com", 35},
+	} {
+		s.users[s.nextID] = &pb.User{
+			Id: s.nextID, Name: u.name, Email: u.email, Age: u.age, Active: true,
+		}
+		s.nextID++
+	}
+	return s
+}
+
+func (s *userServiceServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	user, ok := s.users[req.Id]
+	if !ok || user.Deleted {
+		return nil, status.Errorf(codes.NotFound, "user %d not found", req.Id)
+	}
+	return user, nil
+}
+
+func (s *userServiceServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.User, error) {
+	if req.Name == "" || req.Email == "" {
+		return nil, status.Error(codes.InvalidArgument, "name and email are required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user := &pb.User{
+		Id: s.nextID, Name: req.Name, Email: req.Email, Age: req.Age, Active: true,
+	}
+	s.users[s.nextID] = user
+	s.nextID++
+	return user, nil
+}
+
+func (s *userServiceServer) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user, ok := s.users[req.Id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "user %d not found", req.Id)
+	}
+
+	// With update_fields, exactly the named fields are applied — including
+	// explicit zero values a heuristic could never express (age 0,
+	// active=false). Without it, fall back to the legacy non-zero checks.
+	if len(req.UpdateFields) > 0 {
+		for _, field := range req.UpdateFields {
+			switch field {
+			case "name":
+				user.Name = req.Name
+			case "email":
+				user.Email = req.Email
+			case "age":
+				user.Age = req.Age
+			case "active":
+				user.Active = req.Active
+			default:
+				return nil, status.Errorf(codes.InvalidArgument, "unknown update field %q", field)
+			}
+		}
+		return user, nil
+	}
+
+	if req.Name != "" {
+		user.Name = req.Name
+	}
+	if req.Email != "" {
+		user.Email = req.Email
+	}
+	if req.Age > 0 {
+		user.Age = req.Age
+	}
+	// Active is deliberately untouched here: false is its zero value, so the
+	// legacy path cannot tell "deactivate" from "field omitted". Toggling it
+	// requires update_fields.
+	return user, nil
+}
+
+// DeleteUser soft-deletes: the user stays in the store, hidden from reads,
+// and can be brought back with RestoreUser.
+func (s *userServiceServer) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb.DeleteUserResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user, ok := s.users[req.Id]
+	if !ok || user.Deleted {
+		return nil, status.Errorf(codes.NotFound, "user %d not found", req.Id)
+	}
+	user.Deleted = true
+	user.DeletedAt = time.Now().UTC().Format(time.RFC3339)
+	return &pb.DeleteUserResponse{Success: true}, nil
+}
+
+func (s *userServiceServer) RestoreUser(ctx context.Context, req *pb.RestoreUserRequest) (*pb.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user, ok := s.users[req.Id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "user %d not found", req.Id)
+	}
+	if !user.Deleted {
+		return nil, status.Errorf(codes.FailedPrecondition, "user %d is not deleted", req.Id)
+	}
+	user.Deleted = false
+	user.DeletedAt = ""
+	return user, nil
+}
+
+func (s *userServiceServer) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	page, size := req.Page, req.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > 100 {
+		size = 10
+	}
+
+	s.mu.RLock()
+	all := make([]*pb.User, 0, len(s.users))
+	for _, u := range s.users {
+		if u.Deleted && !req.IncludeDeleted {
+			continue
+		}
+		all = append(all, u)
+	}
+	s.mu.RUnlock()
+	sort.Slice(all, func(i, j int) bool { return all[i].Id < all[j].Id })
+
+	start := int(page-1) * int(size)
+	if start > len(all) {
+		start = len(all)
+	}
+	end := start + int(size)
+	if end > len(all) {
+		end = len(all)
+	}
+
+	// Ceil division; an empty list has zero pages and never a next one.
+	totalPages := (int32(len(all)) + size - 1) / size
+
+	return &pb.ListUsersResponse{
+		Users:      all[start:end],
+		Total:      int32(len(all)),
+		Page:       page,
+		PageSize:   size,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+	}, nil
+}
+
+func (s *userServiceServer) StreamUsers(req *pb.StreamUsersRequest, stream pb.UserService_StreamUsersServer) error {
+	limit := req.Limit
+	if limit < 1 {
+		limit = 10
+	}
+	interval := time.Duration(req.IntervalMs) * time.Millisecond
+
+	s.mu.RLock()
+	all := make([]*pb.User, 0, len(s.users))
+	for _, u := range s.users {
+		if u.Deleted {
+			continue
+		}
+		all = append(all, u)
+	}
+	s.mu.RUnlock()
+	sort.Slice(all, func(i, j int) bool { return all[i].Id < all[j].Id })
+
+	sent := int32(0)
+	for _, u := range all {
+		if sent >= limit {
+			break
+		}
+		if err := stream.Send(u); err != nil {
+			return err
+		}
+		sent++
+		if interval > 0 {
+			select {
+			case <-stream.Context().Done():
+				return stream.Context().Err()
+			case <-time.After(interval):
+			}
+		}
+	}
+	return nil
+}
+
+// newGRPCServer assembles a server with the user service and the standard
+// health service. Reflection is only registered on request: it is handy for
+// grpcurl-style debugging but should stay off in production.
+func newGRPCServer(enableReflection bool, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts, grpc.ChainUnaryInterceptor(defaultTimeoutInterceptor()))
+	grpcServer := grpc.NewServer(opts...)
+	pb.RegisterUserServiceServer(grpcServer, newUserServiceServer())
+	grpc_health_v1.RegisterHealthServer(grpcServer, health.NewServer())
+	if enableReflection {
+		reflection.Register(grpcServer)
+	}
+	return grpcServer
+}
+
+// startServer listens on addr and serves the user service until the listener
+// fails.
+func startServer(addr string, enableReflection bool, opts ...grpc.ServerOption) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen %s: %w", addr, err)
+	}
+
+	log.Printf("gRPC server listening on %s (reflection=%v)", addr, enableReflection)
+	return newGRPCServer(enableReflection, opts...).Serve(lis)
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/lannisite110/hello_world/lesson-03/examples/09-grpc/pb"
+)
+
+func TestUpdateUserWithFieldMask(t *testing.T) {
+	srv := newUserServiceServer()
+	ctx := context.Background()
+
+	before, err := srv.GetUser(ctx, &pb.GetUserRequest{Id: 1})
+	if err != nil {
+		t.Fatalf("get seeded user: %v", err)
+	}
+
+	// Only email is in the mask; the zero-valued name/age in the request
+	// must not clobber the stored values.
+	updated, err := srv.UpdateUser(ctx, &pb.UpdateUserRequest{
+		Id:           1,
+		Email:        "alice@new.example.com",
+		UpdateFields: []string{"email"},
+	})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if updated.Email != "alice@new.example.com" {
+		t.Fatalf("email = %q, not updated", updated.Email)
+	}
+	if updated.Name != before.Name || updated.Age != before.Age || updated.Active != before.Active {
+		t.Fatalf("unmasked fields changed: %+v vs %+v", updated, before)
+	}
+}
+
+func TestUpdateUserMaskAllowsExplicitZero(t *testing.T) {
+	srv := newUserServiceServer()
+	ctx := context.Background()
+
+	updated, err := srv.UpdateUser(ctx, &pb.UpdateUserRequest{
+		Id:           2,
+		Active:       false,
+		Age:          0,
+		UpdateFields: []string{"active", "age"},
+	})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if updated.Active {
+		t.Fatal("active should have been explicitly set to false")
+	}
+	if updated.Age != 0 {
+		t.Fatalf("age = %d, want explicit 0", updated.Age)
+	}
+	if updated.Email == "" || updated.Name == "" {
+		t.Fatalf("masked update touched other fields: %+v", updated)
+	}
+}
+
+func TestUpdateUserLegacyPathLeavesActiveAlone(t *testing.T) {
+	srv := newUserServiceServer()
+	ctx := context.Background()
+
+	before, err := srv.GetUser(ctx, &pb.GetUserRequest{Id: 1})
+	if err != nil {
+		t.Fatalf("get seeded user: %v", err)
+	}
+	if !before.Active {
+		t.Fatal("seeded user 1 should start active")
+	}
+
+	// No update_fields: a legacy update that omits active (so the field
+	// decodes as false) must not deactivate the user.
+	updated, err := srv.UpdateUser(ctx, &pb.UpdateUserRequest{
+		Id:   1,
+		Name: "Alice Renamed",
+	})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if updated.Name != "Alice Renamed" {
+		t.Fatalf("name = %q, not updated", updated.Name)
+	}
+	if !updated.Active {
+		t.Fatal("legacy update without update_fields deactivated the user")
+	}
+
+	// Flipping active still works through the mask path.
+	updated, err = srv.UpdateUser(ctx, &pb.UpdateUserRequest{
+		Id:           1,
+		Active:       false,
+		UpdateFields: []string{"active"},
+	})
+	if err != nil {
+		t.Fatalf("masked update: %v", err)
+	}
+	if updated.Active {
+		t.Fatal("masked update should have deactivated the user")
+	}
+}
+
+func TestUpdateUserUnknownMaskField(t *testing.T) {
+	srv := newUserServiceServer()
+
+	_, err := srv.UpdateUser(context.Background(), &pb.UpdateUserRequest{
+		Id:           1,
+		UpdateFields: []string{"nickname"},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("err = %v, want InvalidArgument for an unknown field", err)
+	}
+}
+
+func TestListUsersPaginationMetadata(t *testing.T) {
+	srv := newUserServiceServer() // seeds three users
+	ctx := context.Background()
+
+	// Two per page over three users: two pages, last one half full.
+	first, err := srv.ListUsers(ctx, &pb.ListUsersRequest{Page: 1, PageSize: 2})
+	if err != nil {
+		t.Fatalf("list page 1: %v", err)
+	}
+	if first.TotalPages != 2 || !first.HasNext {
+		t.Fatalf("page 1: total_pages=%d has_next=%v, want 2/true", first.TotalPages, first.HasNext)
+	}
+
+	last, err := srv.ListUsers(ctx, &pb.ListUsersRequest{Page: 2, PageSize: 2})
+	if err != nil {
+		t.Fatalf("list page 2: %v", err)
+	}
+	if len(last.Users) != 1 {
+		t.Fatalf("last page has %d users, want 1", len(last.Users))
+	}
+	if last.TotalPages != 2 || last.HasNext {
+		t.Fatalf("last page: total_pages=%d has_next=%v, want 2/false", last.TotalPages, last.HasNext)
+	}
+}
+
+func TestListUsersEmptyStore(t *testing.T) {
+	srv := &userServiceServer{users: map[int64]*pb.User{}, nextID: 1}
+
+	resp, err := srv.ListUsers(context.Background(), &pb.ListUsersRequest{Page: 1, PageSize: 10})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if resp.TotalPages != 0 || resp.HasNext {
+		t.Fatalf("empty store: total_pages=%d has_next=%v, want 0/false", resp.TotalPages, resp.HasNext)
+	}
+}
+
+func TestDeleteUserHidesAndRestoreBringsBack(t *testing.T) {
+	srv := newUserServiceServer()
+	ctx := context.Background()
+
+	if _, err := srv.DeleteUser(ctx, &pb.DeleteUserRequest{Id: 1}); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := srv.GetUser(ctx, &pb.GetUserRequest{Id: 1}); status.Code(err) != codes.NotFound {
+		t.Fatalf("deleted user still visible: err = %v", err)
+	}
+	// Deleting twice is the same as deleting a missing user.
+	if _, err := srv.DeleteUser(ctx, &pb.DeleteUserRequest{Id: 1}); status.Code(err) != codes.NotFound {
+		t.Fatalf("double delete: err = %v, want NotFound", err)
+	}
+
+	restored, err := srv.RestoreUser(ctx, &pb.RestoreUserRequest{Id: 1})
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if restored.Deleted || restored.DeletedAt != "" {
+		t.Fatalf("restored user still marked deleted: %+v", restored)
+	}
+	if _, err := srv.GetUser(ctx, &pb.GetUserRequest{Id: 1}); err != nil {
+		t.Fatalf("get restored user: %v", err)
+	}
+}
+
+func TestRestoreUserNotDeleted(t *testing.T) {
+	srv := newUserServiceServer()
+
+	_, err := srv.RestoreUser(context.Background(), &pb.RestoreUserRequest{Id: 1})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("err = %v, want FailedPrecondition for a live user", err)
+	}
+}
+
+func TestListUsersIncludeDeleted(t *testing.T) {
+	srv := newUserServiceServer() // seeds three users
+	ctx := context.Background()
+
+	if _, err := srv.DeleteUser(ctx, &pb.DeleteUserRequest{Id: 2}); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	visible, err := srv.ListUsers(ctx, &pb.ListUsersRequest{Page: 1, PageSize: 10})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if visible.Total != 2 {
+		t.Fatalf("default list total = %d, want 2", visible.Total)
+	}
+
+	all, err := srv.ListUsers(ctx, &pb.ListUsersRequest{Page: 1, PageSize: 10, IncludeDeleted: true})
+	if err != nil {
+		t.Fatalf("list include_deleted: %v", err)
+	}
+	if all.Total != 3 {
+		t.Fatalf("include_deleted total = %d, want 3", all.Total)
+	}
+	var deleted *pb.User
+	for _, u := range all.Users {
+		if u.Id == 2 {
+			deleted = u
+		}
+	}
+	if deleted == nil || !deleted.Deleted || deleted.DeletedAt == "" {
+		t.Fatalf("deleted user missing its markers: %+v", deleted)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/lannisite110/hello_world/lesson-03/examples/09-grpc/pb"
+)
+
+// scriptedStream yields a fixed set of users, failing Recv once the stream
+// context is cancelled, like a real gRPC client stream.
+type scriptedStream struct {
+	grpc.ClientStream
+	ctx   context.Context
+	users []*pb.User
+	pos   int
+}
+
+func (s *scriptedStream) Recv() (*pb.User, error) {
+	if err := s.ctx.Err(); err != nil {
+		return nil, err
+	}
+	if s.pos >= len(s.users) {
+		return nil, io.EOF
+	}
+	u := s.users[s.pos]
+	s.pos++
+	return u, nil
+}
+
+// streamingClient hands out one scriptedStream and remembers the context the
+// stream was opened with.
+type streamingClient struct {
+	fakeUserClient
+	stream    *scriptedStream
+	streamCtx context.Context
+}
+
+func (c *streamingClient) StreamUsers(ctx context.Context, req *pb.StreamUsersRequest, opts ...grpc.CallOption) (pb.UserService_StreamUsersClient, error) {
+	c.streamCtx = ctx
+	c.stream.ctx = ctx
+	return c.stream, nil
+}
+
+func namedUsers(names ...string) []*pb.User {
+	users := make([]*pb.User, len(names))
+	for i, name := range names {
+		users[i] = &pb.User{Id: int64(i + 1), Name: name}
+	}
+	return users
+}
+
+func TestStreamUsersCallbackReceivesAll(t *testing.T) {
+	client := &streamingClient{stream: &scriptedStream{users: namedUsers("a", "b", "c")}}
+
+	var got []string
+	err := streamUsersCallback(context.Background(), client, 3, 0, func(u *pb.User) error {
+		got = append(got, u.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("streamUsersCallback: %v", err)
+	}
+	if len(got) != 3 || got[0] != "a" || got[2] != "c" {
+		t.Fatalf("received %v, want all three users in order", got)
+	}
+}
+
+func TestStreamUsersCallbackStopsOnError(t *testing.T) {
+	client := &streamingClient{stream: &scriptedStream{users: namedUsers("a", "b", "c", "d")}}
+	boom := errors.New("enough")
+
+	calls := 0
+	err := streamUsersCallback(context.Background(), client, 4, 0, func(u *pb.User) error {
+		calls++
+		if calls == 2 {
+			return boom
+		}
+		return nil
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want wrapped callback error", err)
+	}
+	if calls != 2 {
+		t.Fatalf("callback ran %d times, want 2 (stop after the error)", calls)
+	}
+	if client.streamCtx.Err() == nil {
+		t.Fatal("stream context should be cancelled after the callback error")
+	}
+}
@@ -0,0 +1,156 @@
+// Package handlers maps HTTP requests onto the project services.
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lannisite110/hello_world/lesson-03/examples/project/models"
+	"github.com/lannisite110/hello_world/lesson-03/examples/project/services"
+	"github.com/lannisite110/hello_world/lesson-03/examples/project/utils"
+)
+
+// UserHandler exposes the account endpoints.
+type UserHandler struct {
+	users *services.UserService
+}
+
+// NewUserHandler returns a handler backed by the given service.
+func NewUserHandler(users *services.UserService) *UserHandler {
+	return &UserHandler{users: users}
+}
+
+type registerRequest struct {
+	Username string `json:"username" binding:"required,min=3,max=64"`
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=6"`
+	Nickname string `json:"nickname"`
+}
+
+// Register creates an account and returns it with a fresh token.
+func (h *UserHandler) Register(c *gin.Context) {
+	var req registerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	user, err := h.users.CreateUser(c.Request.Context(), req.Username, req.Email, req.Password, req.Nickname)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+	token, err := utils.GenerateToken(user.ID, user.Username)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+	utils.Success(c, gin.H{"user": user.ToResponse(), "token": token})
+}
+
+type loginRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// Login authenticates and returns a token.
+func (h *UserHandler) Login(c *gin.Context) {
+	var req loginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	user, err := h.users.Authenticate(c.Request.Context(), req.Username, req.Password)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCredentials) {
+			utils.Error(c, http.StatusUnauthorized, err.Error())
+			return
+		}
+		utils.HandleError(c, err)
+		return
+	}
+	token, err := utils.GenerateToken(user.ID, user.Username)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+	utils.Success(c, gin.H{"user": user.ToResponse(), "token": token})
+}
+
+// GetProfile returns the authenticated user.
+func (h *UserHandler) GetProfile(c *gin.Context) {
+	userID := c.GetUint("userID")
+	user, err := h.users.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		utils.HandleError(c, err)
+		return
+	}
+	utils.Success(c, user.ToResponse())
+}
+
+type updateProfileRequest struct {
+	Nickname string `json:"nickname"`
+	Email    string `json:"email" binding:"omitempty,email"`
+	// Version must echo the version from the last read so concurrent edits
+	// cannot silently overwrite each other.
+	Version int `json:"version" binding:"required,min=1"`
+}
+
+// UpdateProfile changes the authenticated user's profile fields.
+func (h *UserHandler) UpdateProfile(c *gin.Context) {
+	var req updateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	userID := c.GetUint("userID")
+	user, err := h.users.UpdateUser(c.Request.Context(), userID, req.Nickname, req.Email, req.Version)
+	if err != nil {
+		if errors.Is(err, services.ErrConflict) {
+			utils.HandleError(c, utils.ErrConflict("profile was modified concurrently, reload and retry", err))
+			return
+		}
+		utils.HandleError(c, err)
+		return
+	}
+	utils.Success(c, user.ToResponse())
+}
+
+// ExportUsersCSV streams every user as CSV, fetching rows in batches so the
+// whole table is never held in memory.
+func (h *UserHandler) ExportUsersCSV(c *gin.Context) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="users.csv"`)
+
+	w := csv.NewWriter(c.Writer)
+	if err := w.Write([]string{"id", "username", "email", "nickname", "created_at"}); err != nil {
+		return
+	}
+
+	err := h.users.StreamUsers(500, func(batch []models.User) error {
+		for _, u := range batch {
+			record := []string{
+				strconv.FormatUint(uint64(u.ID), 10),
+				u.Username,
+				u.Email,
+				u.Nickname,
+				u.CreatedAt.Format(time.RFC3339),
+			}
+			if err := w.Write(record); err != nil {
+				return err
+			}
+		}
+		w.Flush()
+		return w.Error()
+	})
+	if err != nil {
+		// Headers are already sent; the truncated output signals the failure.
+		_ = c.Error(err)
+		return
+	}
+	w.Flush()
+}
@@ -0,0 +1,27 @@
+// Lesson 03 project: a small user API with JWT auth, Gin and GORM.
+//
+//	go run .
+package main
+
+import (
+	"log"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/lannisite110/hello_world/lesson-03/examples/project/models"
+)
+
+func main() {
+	db, err := gorm.Open(sqlite.Open("project.db"), &gorm.Config{})
+	if err != nil {
+		log.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(&models.User{}); err != nil {
+		log.Fatalf("migrate: %v", err)
+	}
+
+	if err := buildRouter(db).Run(":8080"); err != nil {
+		log.Fatalf("run: %v", err)
+	}
+}
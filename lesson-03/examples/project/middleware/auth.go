@@ -0,0 +1,60 @@
+// Package middleware holds the project's Gin middleware.
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lannisite110/hello_world/lesson-03/examples/project/utils"
+	"github.com/lannisite110/hello_world/pkg/ctxutil"
+)
+
+// JWTAuth rejects requests without a valid bearer token and stores the
+// authenticated identity on the Gin context.
+func JWTAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			utils.Error(c, http.StatusUnauthorized, "missing authorization header")
+			c.Abort()
+			return
+		}
+		tokenString, found := strings.CutPrefix(header, "Bearer ")
+		if !found {
+			utils.Error(c, http.StatusUnauthorized, "authorization header must be a bearer token")
+			c.Abort()
+			return
+		}
+		claims, err := utils.ParseToken(tokenString)
+		if err != nil {
+			utils.Error(c, http.StatusUnauthorized, "invalid or expired token")
+			c.Abort()
+			return
+		}
+		c.Set("userID", claims.UserID)
+		c.Set("username", claims.Username)
+		c.Set("claims", claims)
+		// Also put the identity on the request context so database hooks
+		// (audit fields) can see who is acting.
+		c.Request = c.Request.WithContext(ctxutil.WithOperator(c.Request.Context(), claims.Username))
+		c.Next()
+	}
+}
+
+// RequireRole guards a route behind one role from the token's roles claim.
+// It must run after JWTAuth; a request that somehow reaches it without
+// claims is treated as forbidden rather than trusted.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, ok := c.Get("claims")
+		claims, _ := value.(*utils.Claims)
+		if !ok || claims == nil || !claims.HasRole(role) {
+			utils.Error(c, http.StatusForbidden, "missing required role: "+role)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// cachedResponse is the replayable part of a completed request.
+type cachedResponse struct {
+	status      int
+	contentType string
+	body        []byte
+	storedAt    time.Time
+}
+
+// IdempotencyStore keeps first responses per Idempotency-Key for a TTL.
+type IdempotencyStore struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cachedResponse
+}
+
+// NewIdempotencyStore returns an empty store whose entries expire after ttl.
+func NewIdempotencyStore(ttl time.Duration) *IdempotencyStore {
+	return &IdempotencyStore{ttl: ttl, entries: make(map[string]cachedResponse)}
+}
+
+func (s *IdempotencyStore) get(key string) (cachedResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	if !ok {
+		return cachedResponse{}, false
+	}
+	if time.Since(entry.storedAt) > s.ttl {
+		delete(s.entries, key)
+		return cachedResponse{}, false
+	}
+	return entry, true
+}
+
+func (s *IdempotencyStore) put(key string, entry cachedResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry.storedAt = time.Now()
+	s.entries[key] = entry
+}
+
+// bodyRecorder tees the response body so it can be cached for replay.
+type bodyRecorder struct {
+	gin.ResponseWriter
+	buf bytes.Buffer
+}
+
+func (r *bodyRecorder) Write(p []byte) (int, error) {
+	r.buf.Write(p)
+	return r.ResponseWriter.Write(p)
+}
+
+// Idempotency replays the first response for repeated POSTs carrying the
+// same Idempotency-Key header, so retried requests cannot create a resource
+// twice. Requests without the header pass through untouched.
+func Idempotency(store *IdempotencyStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("Idempotency-Key")
+		if c.Request.Method != "POST" || key == "" {
+			c.Next()
+			return
+		}
+
+		if entry, ok := store.get(key); ok {
+			c.Header("Idempotency-Replayed", "true")
+			c.Data(entry.status, entry.contentType, entry.body)
+			c.Abort()
+			return
+		}
+
+		recorder := &bodyRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		store.put(key, cachedResponse{
+			status:      recorder.Status(),
+			contentType: recorder.Header().Get("Content-Type"),
+			body:        recorder.buf.Bytes(),
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Package models defines the database models for the lesson-03 project.
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/lannisite110/hello_world/lesson-02/gormx"
+)
+
+// User is an account row. The password column stores a bcrypt hash, never
+// the plain text. Deletes are soft: gorm.DeletedAt keeps the row but hides
+// it from normal queries. The embedded audit mixin fills CreatedBy/UpdatedBy
+// from the operator carried in the request context by the auth middleware.
+type User struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	Username  string         `gorm:"uniqueIndex;size:64;not null" json:"username"`
+	Email     string         `gorm:"uniqueIndex;size:128;not null" json:"email"`
+	Password  string         `gorm:"size:128;not null" json:"-"`
+	Nickname  string         `gorm:"size:64" json:"nickname"`
+	Version   int            `gorm:"not null;default:1" json:"version"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	gormx.AuditFields
+}
+
+// UserResponse is the API-facing view of a user, without credentials.
+type UserResponse struct {
+	ID        uint      `json:"id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	Nickname  string    `json:"nickname"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ToResponse converts the model into its API representation.
+func (u *User) ToResponse() UserResponse {
+	return UserResponse{
+		ID:        u.ID,
+		Username:  u.Username,
+		Email:     u.Email,
+		Nickname:  u.Nickname,
+		Version:   u.Version,
+		CreatedAt: u.CreatedAt,
+	}
+}
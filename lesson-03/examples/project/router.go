@@ -0,0 +1,36 @@
+package main
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/lannisite110/hello_world/lesson-03/examples/project/handlers"
+	"github.com/lannisite110/hello_world/lesson-03/examples/project/middleware"
+	"github.com/lannisite110/hello_world/lesson-03/examples/project/services"
+	"github.com/lannisite110/hello_world/lesson-03/ginmw"
+)
+
+// buildRouter wires services, handlers and middleware onto a Gin engine.
+func buildRouter(db *gorm.DB) *gin.Engine {
+	userHandler := handlers.NewUserHandler(services.NewUserService(db))
+
+	r := gin.Default()
+	jsonOnly := ginmw.RequireContentType("application/json")
+	idempotent := middleware.Idempotency(middleware.NewIdempotencyStore(24 * time.Hour))
+
+	api := r.Group("/api/v1")
+	{
+		api.POST("/register", jsonOnly, idempotent, userHandler.Register)
+		api.POST("/login", jsonOnly, userHandler.Login)
+
+		authed := api.Group("", middleware.JWTAuth())
+		{
+			authed.GET("/profile", userHandler.GetProfile)
+			authed.GET("/users/export.csv", middleware.RequireRole("admin"), userHandler.ExportUsersCSV)
+			authed.PUT("/profile", jsonOnly, userHandler.UpdateProfile)
+		}
+	}
+	return r
+}
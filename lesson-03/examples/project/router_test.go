@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/lannisite110/hello_world/lesson-03/examples/project/models"
+	"github.com/lannisite110/hello_world/lesson-03/examples/project/services"
+	"github.com/lannisite110/hello_world/lesson-03/examples/project/utils"
+)
+
+// newProjectDB opens a throwaway SQLite database with the project schema.
+func newProjectDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "project.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	if err := db.AutoMigrate(&models.User{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
+
+func TestExportUsersCSV(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := newProjectDB(t)
+	svc := services.NewUserService(db)
+
+	const n = 12
+	for i := 0; i < n; i++ {
+		_, err := svc.CreateUser(
+			context.Background(),
+			fmt.Sprintf("user%02d", i),
+			fmt.Sprintf("user%02d@example.com", i),
+			"password123",
+			"")
+		if err != nil {
+			t.Fatalf("seed user %d: %v", i, err)
+		}
+	}
+
+	// The export endpoint is admin-only.
+	token, err := utils.GenerateToken(1, "user00", "admin")
+	if err != nil {
+		t.Fatalf("token: %v", err)
+	}
+
+	r := buildRouter(db)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/export.csv", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (%s)", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Content-Disposition"); !strings.Contains(got, "users.csv") {
+		t.Errorf("Content-Disposition = %q", got)
+	}
+
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("parse csv: %v", err)
+	}
+	if len(records) != n+1 {
+		t.Fatalf("got %d csv lines, want %d (header + %d rows)", len(records), n+1, n)
+	}
+	wantHeader := []string{"id", "username", "email", "nickname", "created_at"}
+	for i, col := range wantHeader {
+		if records[0][i] != col {
+			t.Fatalf("header = %v, want %v", records[0], wantHeader)
+		}
+	}
+}
+
+func TestExportUsersCSVRequiresAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := buildRouter(newProjectDB(t))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/users/export.csv", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401", w.Code)
+	}
+}
+
+func TestExportUsersCSVRequiresAdminRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := buildRouter(newProjectDB(t))
+
+	// Authenticated, but without the admin role: forbidden, not unauthorized.
+	token, err := utils.GenerateToken(1, "user00", "viewer")
+	if err != nil {
+		t.Fatalf("token: %v", err)
+	}
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/export.csv", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want 403 (%s)", w.Code, w.Body.String())
+	}
+}
+
+func TestRegisterIdempotencyKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := newProjectDB(t)
+	r := buildRouter(db)
+
+	body := `{"username":"carol","email":"carol@example.com","password":"password123"}`
+	post := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/register", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "reg-123")
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	first := post()
+	if first.Code != http.StatusOK {
+		t.Fatalf("first status = %d (%s)", first.Code, first.Body.String())
+	}
+
+	second := post()
+	if second.Code != first.Code {
+		t.Fatalf("replayed status = %d, want %d", second.Code, first.Code)
+	}
+	if second.Body.String() != first.Body.String() {
+		t.Fatalf("replayed body differs:\nfirst:  %s\nsecond: %s", first.Body.String(), second.Body.String())
+	}
+	if second.Header().Get("Idempotency-Replayed") != "true" {
+		t.Error("missing Idempotency-Replayed header on replay")
+	}
+
+	var count int64
+	db.Model(&models.User{}).Count(&count)
+	if count != 1 {
+		t.Fatalf("user count = %d, want 1 (no duplicate creation)", count)
+	}
+}
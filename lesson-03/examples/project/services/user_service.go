@@ -0,0 +1,123 @@
+// Package services implements the project's business logic on top of GORM.
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+
+	"github.com/lannisite110/hello_world/lesson-03/examples/project/models"
+)
+
+// ErrInvalidCredentials is returned when login fails; it deliberately does
+// not reveal whether the user exists.
+var ErrInvalidCredentials = errors.New("invalid username or password")
+
+// ErrConflict is returned when an update carries a stale version: someone
+// else changed the row since the caller last read it.
+var ErrConflict = errors.New("user was modified concurrently")
+
+// UserService owns all user persistence operations.
+type UserService struct {
+	db *gorm.DB
+}
+
+// NewUserService returns a service backed by db.
+func NewUserService(db *gorm.DB) *UserService {
+	return &UserService{db: db}
+}
+
+// CreateUser registers a new account with a bcrypt-hashed password.
+func (s *UserService) CreateUser(ctx context.Context, username, email, password, nickname string) (*models.User, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("hash password: %w", err)
+	}
+	user := &models.User{
+		Username: username,
+		Email:    email,
+		Password: string(hash),
+		Nickname: nickname,
+	}
+	if err := s.db.WithContext(ctx).Create(user).Error; err != nil {
+		return nil, fmt.Errorf("create user: %w", err)
+	}
+	return user, nil
+}
+
+// Authenticate checks the credentials and returns the matching user.
+func (s *UserService) Authenticate(ctx context.Context, username, password string) (*models.User, error) {
+	var user models.User
+	if err := s.db.WithContext(ctx).Where("username = ?", username).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)) != nil {
+		return nil, ErrInvalidCredentials
+	}
+	return &user, nil
+}
+
+// GetUserByID loads one user.
+func (s *UserService) GetUserByID(ctx context.Context, id uint) (*models.User, error) {
+	var user models.User
+	if err := s.db.WithContext(ctx).First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UpdateUser applies the non-empty profile fields to the user, using
+// optimistic locking: the update only lands when version still matches the
+// stored row, and every successful update bumps the version. A stale version
+// comes back as ErrConflict so the caller can re-read and retry.
+func (s *UserService) UpdateUser(ctx context.Context, id uint, nickname, email string, version int) (*models.User, error) {
+	user, err := s.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	updates := map[string]any{}
+	if nickname != "" {
+		updates["nickname"] = nickname
+	}
+	if email != "" {
+		updates["email"] = email
+	}
+	if len(updates) == 0 {
+		if user.Version != version {
+			return nil, ErrConflict
+		}
+		return user, nil
+	}
+
+	updates["version"] = gorm.Expr("version + 1")
+	res := s.db.WithContext(ctx).Model(user).
+		Where("version = ?", version).
+		Updates(updates)
+	if res.Error != nil {
+		return nil, fmt.Errorf("update user: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return nil, ErrConflict
+	}
+	return s.GetUserByID(ctx, id)
+}
+
+// StreamUsers walks the whole user table in batches of batchSize, invoking
+// fn per batch so callers can stream without loading every row at once. A
+// non-nil error from fn stops the scan and is returned.
+func (s *UserService) StreamUsers(batchSize int, fn func([]models.User) error) error {
+	if batchSize < 1 {
+		batchSize = 100
+	}
+	var batch []models.User
+	result := s.db.FindInBatches(&batch, batchSize, func(tx *gorm.DB, n int) error {
+		return fn(batch)
+	})
+	return result.Error
+}
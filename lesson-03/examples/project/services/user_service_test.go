@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/lannisite110/hello_world/lesson-03/examples/project/models"
+	"github.com/lannisite110/hello_world/pkg/ctxutil"
+)
+
+func newServiceDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "svc.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	if err := db.AutoMigrate(&models.User{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
+
+func TestQueriesHonorCancelledContext(t *testing.T) {
+	svc := NewUserService(newServiceDB(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := svc.GetUserByID(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Fatalf("GetUserByID err = %v, want context.Canceled", err)
+	}
+	if _, err := svc.CreateUser(ctx, "x", "x@example.com", "password123", ""); !errors.Is(err, context.Canceled) {
+		t.Fatalf("CreateUser err = %v, want context.Canceled", err)
+	}
+}
+
+func TestCreateAndAuthenticateWithContext(t *testing.T) {
+	svc := NewUserService(newServiceDB(t))
+	ctx := context.Background()
+
+	if _, err := svc.CreateUser(ctx, "dave", "dave@example.com", "password123", "Dave"); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	user, err := svc.Authenticate(ctx, "dave", "password123")
+	if err != nil {
+		t.Fatalf("authenticate: %v", err)
+	}
+	if user.Username != "dave" {
+		t.Fatalf("username = %q", user.Username)
+	}
+}
+
+func TestUpdateRecordsOperatorAudit(t *testing.T) {
+	svc := NewUserService(newServiceDB(t))
+	ctx := ctxutil.WithOperator(context.Background(), "dave")
+
+	created, err := svc.CreateUser(ctx, "dave", "dave2@example.com", "password123", "")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if created.CreatedBy != "dave" {
+		t.Fatalf("CreatedBy = %q, want dave", created.CreatedBy)
+	}
+
+	updated, err := svc.UpdateUser(ctx, created.ID, "Davey", "", created.Version)
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	fresh, err := svc.GetUserByID(ctx, updated.ID)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if fresh.UpdatedBy != "dave" {
+		t.Fatalf("UpdatedBy = %q, want dave", fresh.UpdatedBy)
+	}
+}
+
+func TestSoftDeleteHidesUser(t *testing.T) {
+	db := newServiceDB(t)
+	svc := NewUserService(db)
+	ctx := context.Background()
+
+	user, err := svc.CreateUser(ctx, "gone", "gone@example.com", "password123", "")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := db.Delete(&models.User{}, user.ID).Error; err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	if _, err := svc.GetUserByID(ctx, user.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("err = %v, want ErrRecordNotFound after soft delete", err)
+	}
+
+	// The row still exists physically.
+	var count int64
+	db.Unscoped().Model(&models.User{}).Where("id = ?", user.ID).Count(&count)
+	if count != 1 {
+		t.Fatalf("unscoped count = %d, want 1", count)
+	}
+}
+
+func TestUpdateUserOptimisticLocking(t *testing.T) {
+	svc := NewUserService(newServiceDB(t))
+	ctx := context.Background()
+
+	created, err := svc.CreateUser(ctx, "eve", "eve@example.com", "password123", "")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if created.Version != 1 {
+		t.Fatalf("fresh user version = %d, want 1", created.Version)
+	}
+
+	updated, err := svc.UpdateUser(ctx, created.ID, "Evie", "", created.Version)
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if updated.Version != 2 {
+		t.Fatalf("version after update = %d, want 2", updated.Version)
+	}
+	if updated.Nickname != "Evie" {
+		t.Fatalf("nickname = %q", updated.Nickname)
+	}
+
+	// A second writer still holding version 1 must be rejected, and its
+	// change must not land.
+	if _, err := svc.UpdateUser(ctx, created.ID, "Evil", "", created.Version); !errors.Is(err, ErrConflict) {
+		t.Fatalf("stale update err = %v, want ErrConflict", err)
+	}
+	fresh, err := svc.GetUserByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if fresh.Nickname != "Evie" || fresh.Version != 2 {
+		t.Fatalf("stale update changed the row: %+v", fresh)
+	}
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// AppError is a typed application error carrying the HTTP status and a
+// stable string code clients can switch on, independent of the
+// human-readable message. Wrap a cause with the constructors' %w-style err
+// argument so errors.Is/As keep working through it.
+type AppError struct {
+	Status  int
+	Code    string
+	Message string
+	Err     error
+}
+
+func (e *AppError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+func (e *AppError) Unwrap() error { return e.Err }
+
+// newAppError builds one taxonomy entry; err may be nil.
+func newAppError(status int, code, message string, err error) *AppError {
+	return &AppError{Status: status, Code: code, Message: message, Err: err}
+}
+
+// The error taxonomy. Each constructor fixes the status and stable code;
+// message is per-call and err is an optional wrapped cause.
+func ErrNotFound(message string, err error) *AppError {
+	return newAppError(http.StatusNotFound, "NOT_FOUND", message, err)
+}
+
+func ErrUnauthorized(message string, err error) *AppError {
+	return newAppError(http.StatusUnauthorized, "UNAUTHORIZED", message, err)
+}
+
+func ErrConflict(message string, err error) *AppError {
+	return newAppError(http.StatusConflict, "CONFLICT", message, err)
+}
+
+func ErrValidation(message string, err error) *AppError {
+	return newAppError(http.StatusBadRequest, "VALIDATION", message, err)
+}
+
+func ErrInternal(message string, err error) *AppError {
+	return newAppError(http.StatusInternalServerError, "INTERNAL", message, err)
+}
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func TestHandleErrorTaxonomy(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"not found", ErrNotFound("user missing", nil), http.StatusNotFound, "NOT_FOUND"},
+		{"unauthorized", ErrUnauthorized("bad token", nil), http.StatusUnauthorized, "UNAUTHORIZED"},
+		{"conflict", ErrConflict("username taken", nil), http.StatusConflict, "CONFLICT"},
+		{"validation", ErrValidation("email required", nil), http.StatusBadRequest, "VALIDATION"},
+		{"internal", ErrInternal("db exploded", nil), http.StatusInternalServerError, "INTERNAL"},
+		{"gorm not found", gorm.ErrRecordNotFound, http.StatusNotFound, "NOT_FOUND"},
+		{"unknown", errors.New("surprise"), http.StatusInternalServerError, "INTERNAL"},
+		{"wrapped app error", fmt.Errorf("load user: %w", ErrConflict("dup", nil)), http.StatusConflict, "CONFLICT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, status := doRequest(t, "", func(c *gin.Context) {
+				HandleError(c, tt.err)
+			})
+			if status != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", status, tt.wantStatus)
+			}
+			if env.ErrorCode != tt.wantCode {
+				t.Fatalf("error_code = %q, want %q", env.ErrorCode, tt.wantCode)
+			}
+			if env.Code != tt.wantStatus {
+				t.Fatalf("code = %d, want %d", env.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestAppErrorWrapsCause(t *testing.T) {
+	cause := errors.New("disk full")
+	err := ErrInternal("save failed", cause)
+
+	if !errors.Is(err, cause) {
+		t.Fatal("errors.Is must see the wrapped cause")
+	}
+	if got := err.Error(); got != "save failed: disk full" {
+		t.Fatalf("Error() = %q", got)
+	}
+	if got := ErrNotFound("gone", nil).Error(); got != "gone" {
+		t.Fatalf("Error() without cause = %q", got)
+	}
+}
+
+func TestHandleErrorHidesInternalDetails(t *testing.T) {
+	env, _ := doRequest(t, "", func(c *gin.Context) {
+		HandleError(c, errors.New("password=hunter2 leaked into error"))
+	})
+	if env.Message != "internal error" {
+		t.Fatalf("message = %q, internal details must not leak", env.Message)
+	}
+}
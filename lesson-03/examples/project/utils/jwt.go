@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// tokenTTL is how long issued tokens stay valid.
+const tokenTTL = 24 * time.Hour
+
+// ErrInvalidToken is returned by ParseToken for expired or tampered tokens.
+var ErrInvalidToken = errors.New("invalid token")
+
+// jwtSecret comes from the environment in real deployments; the fallback
+// keeps the demo runnable out of the box.
+func jwtSecret() []byte {
+	if s := os.Getenv("JWT_SECRET"); s != "" {
+		return []byte(s)
+	}
+	return []byte("lesson03-dev-secret")
+}
+
+// Claims is the token payload.
+type Claims struct {
+	UserID   uint     `json:"user_id"`
+	Username string   `json:"username"`
+	Roles    []string `json:"roles,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// HasRole reports whether the token grants the given role.
+func (c *Claims) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateToken issues a signed token for the user, embedding any roles the
+// caller grants.
+func GenerateToken(userID uint, username string, roles ...string) (string, error) {
+	claims := Claims{
+		UserID:   userID,
+		Username: username,
+		Roles:    roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(tokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Issuer:    "lesson03-project",
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(jwtSecret())
+}
+
+// ParseToken validates the token and returns its claims.
+func ParseToken(tokenString string) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(t *jwt.Token) (any, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+		return jwtSecret(), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
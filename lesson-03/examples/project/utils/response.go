@@ -0,0 +1,74 @@
+// Package utils holds response helpers and token handling for the project.
+package utils
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/lannisite110/hello_world/pkg/ctxutil"
+)
+
+// Envelope is the body shape every API response uses: a stable code (0 for
+// success, the HTTP status otherwise), a human-readable message, the payload,
+// and the request ID when one is in the context.
+type Envelope struct {
+	Code      int    `json:"code"`
+	Message   string `json:"message"`
+	Data      any    `json:"data"`
+	RequestID string `json:"request_id,omitempty"`
+	ErrorCode string `json:"error_code,omitempty"`
+}
+
+// respond writes the envelope, filling request_id from the request context.
+func respond(c *gin.Context, status, code int, message string, data any) {
+	respondCoded(c, status, code, "", message, data)
+}
+
+// respondCoded is respond plus the stable error code from the taxonomy.
+func respondCoded(c *gin.Context, status, code int, errorCode, message string, data any) {
+	env := Envelope{Code: code, Message: message, Data: data, ErrorCode: errorCode}
+	if id, ok := ctxutil.RequestID(c.Request.Context()); ok {
+		env.RequestID = id
+	}
+	c.JSON(status, env)
+}
+
+// Respond writes data in the standard envelope under the given HTTP status:
+// 2xx responses carry code 0 and message "ok", anything else mirrors the
+// status as the code with its standard text.
+func Respond(c *gin.Context, status int, data any) {
+	if status < http.StatusBadRequest {
+		respond(c, status, 0, "ok", data)
+		return
+	}
+	respond(c, status, status, http.StatusText(status), data)
+}
+
+// Success writes a 200 response with the standard body shape.
+func Success(c *gin.Context, data any) {
+	Respond(c, http.StatusOK, data)
+}
+
+// Error writes an error response with the given HTTP status.
+func Error(c *gin.Context, status int, message string) {
+	respond(c, status, status, message, nil)
+}
+
+// HandleError maps errors to HTTP responses. Typed *AppError values drive
+// the status, stable error code and message; gorm.ErrRecordNotFound maps to
+// the NOT_FOUND entry; anything else becomes an opaque 500 so internal
+// details never leak.
+func HandleError(c *gin.Context, err error) {
+	var appErr *AppError
+	switch {
+	case errors.As(err, &appErr):
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		appErr = ErrNotFound("not found", err)
+	default:
+		appErr = ErrInternal("internal error", err)
+	}
+	respondCoded(c, appErr.Status, appErr.Status, appErr.Code, appErr.Message, nil)
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lannisite110/hello_world/pkg/ctxutil"
+)
+
+// doRequest runs handler on a GET request, optionally with a request ID in
+// the context, and returns the decoded envelope plus the HTTP status.
+func doRequest(t *testing.T, requestID string, handler gin.HandlerFunc) (Envelope, int) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	if requestID != "" {
+		ctx := ctxutil.WithRequestID(c.Request.Context(), requestID)
+		c.Request = c.Request.WithContext(ctx)
+	}
+
+	handler(c)
+
+	var env Envelope
+	if err := json.Unmarshal(w.Body.Bytes(), &env); err != nil {
+		t.Fatalf("decode envelope from %q: %v", w.Body.String(), err)
+	}
+	return env, w.Code
+}
+
+func TestSuccessEnvelope(t *testing.T) {
+	env, status := doRequest(t, "req-42", func(c *gin.Context) {
+		Success(c, gin.H{"name": "alice"})
+	})
+
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, want 200", status)
+	}
+	if env.Code != 0 || env.Message != "ok" {
+		t.Fatalf("envelope = %+v, want code 0 message ok", env)
+	}
+	data, ok := env.Data.(map[string]any)
+	if !ok || data["name"] != "alice" {
+		t.Fatalf("data = %#v, want the payload", env.Data)
+	}
+	if env.RequestID != "req-42" {
+		t.Fatalf("request_id = %q, want req-42", env.RequestID)
+	}
+}
+
+func TestErrorEnvelope(t *testing.T) {
+	env, status := doRequest(t, "req-43", func(c *gin.Context) {
+		Error(c, http.StatusConflict, "already exists")
+	})
+
+	if status != http.StatusConflict {
+		t.Fatalf("status = %d, want 409", status)
+	}
+	if env.Code != http.StatusConflict || env.Message != "already exists" {
+		t.Fatalf("envelope = %+v, want code 409 with message", env)
+	}
+	if env.RequestID != "req-43" {
+		t.Fatalf("request_id = %q, want req-43", env.RequestID)
+	}
+}
+
+func TestEnvelopeWithoutRequestID(t *testing.T) {
+	env, _ := doRequest(t, "", func(c *gin.Context) {
+		Success(c, nil)
+	})
+	if env.RequestID != "" {
+		t.Fatalf("request_id = %q, want empty when not in context", env.RequestID)
+	}
+}
+
+func TestRespondErrorStatus(t *testing.T) {
+	env, status := doRequest(t, "", func(c *gin.Context) {
+		Respond(c, http.StatusServiceUnavailable, nil)
+	})
+	if status != http.StatusServiceUnavailable || env.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status/code = %d/%d, want 503/503", status, env.Code)
+	}
+	if env.Message != http.StatusText(http.StatusServiceUnavailable) {
+		t.Fatalf("message = %q, want standard status text", env.Message)
+	}
+}
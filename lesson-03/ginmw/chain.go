@@ -0,0 +1,32 @@
+package ginmw
+
+import "github.com/gin-gonic/gin"
+
+// Chain composes several middleware into one handler so a named stack can be
+// attached to a single route. Each middleware runs in order; once one aborts
+// the context, the rest are skipped.
+//
+// Chain calls each middleware as a plain function, so it is only suitable for
+// barrier handlers that do their work inline and return — auth checks,
+// header stamping, request validation. Middleware that wrap the rest of the
+// request via c.Next() (Gzip, ETag, ServerTiming, Trace, ...) do not nest
+// here: their c.Next() runs the route's remaining handlers immediately, so
+// Chain(A, B) executes A-pre, handler, A-post, B-pre, B-post instead of
+// A-pre, B-pre, handler, B-post, A-post. Register those directly on the
+// route or group (or via Apply) so gin can splice them into its own chain.
+func Chain(middlewares ...gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, mw := range middlewares {
+			mw(c)
+			if c.IsAborted() {
+				return
+			}
+		}
+	}
+}
+
+// Apply attaches the middleware stack to a router group, mirroring Chain for
+// whole groups.
+func Apply(group *gin.RouterGroup, middlewares ...gin.HandlerFunc) {
+	group.Use(middlewares...)
+}
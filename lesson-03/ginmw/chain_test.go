@@ -0,0 +1,160 @@
+package ginmw
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func recordStep(steps *[]string, name string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		*steps = append(*steps, name)
+	}
+}
+
+func TestChainRunsInOrder(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var steps []string
+
+	r := gin.New()
+	r.GET("/x", Chain(
+		recordStep(&steps, "first"),
+		recordStep(&steps, "second"),
+	), func(c *gin.Context) {
+		steps = append(steps, "handler")
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/x", nil))
+
+	want := []string{"first", "second", "handler"}
+	if len(steps) != len(want) {
+		t.Fatalf("steps = %v, want %v", steps, want)
+	}
+	for i := range want {
+		if steps[i] != want[i] {
+			t.Fatalf("steps = %v, want %v", steps, want)
+		}
+	}
+}
+
+func TestChainAbortSkipsRest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var steps []string
+
+	r := gin.New()
+	r.GET("/x", Chain(
+		recordStep(&steps, "first"),
+		func(c *gin.Context) {
+			steps = append(steps, "abort")
+			c.AbortWithStatus(http.StatusForbidden)
+		},
+		recordStep(&steps, "never"),
+	), func(c *gin.Context) {
+		steps = append(steps, "handler")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/x", nil))
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want 403", w.Code)
+	}
+	if len(steps) != 2 || steps[0] != "first" || steps[1] != "abort" {
+		t.Fatalf("steps = %v, want [first abort]", steps)
+	}
+}
+
+// wrapStep is a c.Next()-style middleware: it records work on both sides of
+// the rest of the chain, like Gzip or ETag do.
+func wrapStep(steps *[]string, name string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		*steps = append(*steps, name+"-pre")
+		c.Next()
+		*steps = append(*steps, name+"-post")
+	}
+}
+
+// TestChainDoesNotNestNextMiddleware pins the documented restriction: Chain
+// invokes middleware as plain functions, so c.Next() inside one runs the
+// route's remaining handlers immediately rather than the next chained
+// middleware. Next-calling middleware must be registered directly on the
+// route instead (second half of the test).
+func TestChainDoesNotNestNextMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var steps []string
+
+	r := gin.New()
+	r.GET("/chained", Chain(
+		wrapStep(&steps, "a"),
+		wrapStep(&steps, "b"),
+	), func(c *gin.Context) {
+		steps = append(steps, "handler")
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/chained", nil))
+
+	// Not a-pre, b-pre, handler, b-post, a-post: a's Next() already ran the
+	// handler before b was ever entered.
+	want := []string{"a-pre", "handler", "a-post", "b-pre", "b-post"}
+	if len(steps) != len(want) {
+		t.Fatalf("chained steps = %v, want %v", steps, want)
+	}
+	for i := range want {
+		if steps[i] != want[i] {
+			t.Fatalf("chained steps = %v, want %v", steps, want)
+		}
+	}
+
+	// Registered directly, gin nests them properly.
+	steps = nil
+	r2 := gin.New()
+	r2.GET("/direct",
+		wrapStep(&steps, "a"),
+		wrapStep(&steps, "b"),
+		func(c *gin.Context) {
+			steps = append(steps, "handler")
+			c.Status(http.StatusOK)
+		})
+
+	w = httptest.NewRecorder()
+	r2.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/direct", nil))
+
+	want = []string{"a-pre", "b-pre", "handler", "b-post", "a-post"}
+	if len(steps) != len(want) {
+		t.Fatalf("direct steps = %v, want %v", steps, want)
+	}
+	for i := range want {
+		if steps[i] != want[i] {
+			t.Fatalf("direct steps = %v, want %v", steps, want)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var steps []string
+
+	r := gin.New()
+	group := r.Group("/api")
+	Apply(group, recordStep(&steps, "mw"))
+	group.GET("/x", func(c *gin.Context) {
+		steps = append(steps, "handler")
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/x", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if len(steps) != 2 || steps[0] != "mw" {
+		t.Fatalf("steps = %v, want [mw handler]", steps)
+	}
+}
@@ -0,0 +1,42 @@
+// Package ginmw collects Gin middleware shared by the lesson-03 examples.
+package ginmw
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writeMethods are the HTTP methods carrying request bodies that
+// RequireContentType inspects.
+var writeMethods = map[string]bool{
+	http.MethodPost:  true,
+	http.MethodPut:   true,
+	http.MethodPatch: true,
+}
+
+// RequireContentType rejects write requests whose Content-Type is not in the
+// allowlist with 415 Unsupported Media Type. Parameters like charset are
+// ignored when matching. Reads (GET, HEAD, ...) pass through untouched.
+func RequireContentType(types ...string) gin.HandlerFunc {
+	allowed := make(map[string]bool, len(types))
+	for _, t := range types {
+		allowed[t] = true
+	}
+	return func(c *gin.Context) {
+		if !writeMethods[c.Request.Method] {
+			c.Next()
+			return
+		}
+		mediaType, _, err := mime.ParseMediaType(c.GetHeader("Content-Type"))
+		if err != nil || !allowed[mediaType] {
+			c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, gin.H{
+				"error":   "unsupported media type",
+				"allowed": types,
+			})
+			return
+		}
+		c.Next()
+	}
+}
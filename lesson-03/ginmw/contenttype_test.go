@@ -0,0 +1,59 @@
+package ginmw
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newTestRouter(types ...string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/items", RequireContentType(types...), func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{"ok": true})
+	})
+	r.GET("/items", RequireContentType(types...), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	return r
+}
+
+func TestRequireContentTypeAccepts(t *testing.T) {
+	r := newTestRouter("application/json")
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want 201", w.Code)
+	}
+}
+
+func TestRequireContentTypeRejects(t *testing.T) {
+	r := newTestRouter("application/json")
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader("a=b"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("status = %d, want 415", w.Code)
+	}
+}
+
+func TestRequireContentTypeIgnoresReads(t *testing.T) {
+	r := newTestRouter("application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+}
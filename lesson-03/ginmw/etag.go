@@ -0,0 +1,47 @@
+package ginmw
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ETag buffers GET responses, hashes the body into a strong ETag header, and
+// answers If-None-Match revalidations with 304 Not Modified and no body.
+// Only successful GETs are tagged; other methods and error responses pass
+// through untouched.
+func ETag() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		bw := &bufferingWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = bw
+		c.Next()
+		c.Writer = bw.ResponseWriter
+
+		body := bw.buf.Bytes()
+		if bw.status != http.StatusOK {
+			c.Writer.WriteHeader(bw.status)
+			c.Writer.Write(body)
+			return
+		}
+
+		sum := sha256.Sum256(body)
+		etag := fmt.Sprintf("%q", hex.EncodeToString(sum[:16]))
+		c.Writer.Header().Set("ETag", etag)
+
+		if c.GetHeader("If-None-Match") == etag {
+			c.Writer.Header().Del("Content-Length")
+			c.Writer.WriteHeader(http.StatusNotModified)
+			return
+		}
+		c.Writer.WriteHeader(bw.status)
+		c.Writer.Write(body)
+	}
+}
@@ -0,0 +1,81 @@
+package ginmw
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func etagRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(ETag())
+	r.GET("/doc", func(c *gin.Context) {
+		c.String(http.StatusOK, "the cacheable document")
+	})
+	r.GET("/missing", func(c *gin.Context) {
+		c.String(http.StatusNotFound, "nope")
+	})
+	r.POST("/doc", func(c *gin.Context) {
+		c.String(http.StatusOK, "created")
+	})
+	return r
+}
+
+func TestETagRoundTrip(t *testing.T) {
+	r := etagRouter()
+
+	first := httptest.NewRecorder()
+	r.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/doc", nil))
+	if first.Code != http.StatusOK {
+		t.Fatalf("first status = %d", first.Code)
+	}
+	etag := first.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("no ETag on first response")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/doc", nil)
+	req.Header.Set("If-None-Match", etag)
+	second := httptest.NewRecorder()
+	r.ServeHTTP(second, req)
+	if second.Code != http.StatusNotModified {
+		t.Fatalf("revalidation status = %d, want 304", second.Code)
+	}
+	if second.Body.Len() != 0 {
+		t.Fatalf("304 carried a body: %q", second.Body.String())
+	}
+	if second.Header().Get("ETag") != etag {
+		t.Fatalf("304 ETag = %q, want %q", second.Header().Get("ETag"), etag)
+	}
+}
+
+func TestETagStaleValidatorGetsFullResponse(t *testing.T) {
+	r := etagRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/doc", nil)
+	req.Header.Set("If-None-Match", `"0000"`)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || w.Body.String() != "the cacheable document" {
+		t.Fatalf("stale validator: status=%d body=%q", w.Code, w.Body.String())
+	}
+}
+
+func TestETagSkipsErrorsAndNonGET(t *testing.T) {
+	r := etagRouter()
+
+	notFound := httptest.NewRecorder()
+	r.ServeHTTP(notFound, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	if notFound.Code != http.StatusNotFound || notFound.Header().Get("ETag") != "" {
+		t.Fatalf("error response tagged: status=%d etag=%q", notFound.Code, notFound.Header().Get("ETag"))
+	}
+
+	post := httptest.NewRecorder()
+	r.ServeHTTP(post, httptest.NewRequest(http.MethodPost, "/doc", nil))
+	if post.Header().Get("ETag") != "" {
+		t.Fatalf("POST response tagged: %q", post.Header().Get("ETag"))
+	}
+}
@@ -0,0 +1,70 @@
+package ginmw
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bufferingWriter captures the response instead of flushing it, so a
+// middleware can inspect the complete body before deciding how to send it.
+// Shared by the gzip and etag middleware.
+type bufferingWriter struct {
+	gin.ResponseWriter
+	buf    bytes.Buffer
+	status int
+}
+
+func (w *bufferingWriter) WriteHeader(code int) { w.status = code }
+
+func (w *bufferingWriter) Write(p []byte) (int, error) { return w.buf.Write(p) }
+
+func (w *bufferingWriter) WriteString(s string) (int, error) { return w.buf.WriteString(s) }
+
+// acceptsGzip reports whether the Accept-Encoding header value includes gzip.
+func acceptsGzip(acceptEncoding string) bool {
+	for _, enc := range strings.Split(acceptEncoding, ",") {
+		if strings.TrimSpace(strings.SplitN(enc, ";", 2)[0]) == "gzip" {
+			return true
+		}
+	}
+	return false
+}
+
+// Gzip compresses responses of at least minSize bytes for clients that send
+// Accept-Encoding: gzip. Responses that already carry a Content-Encoding are
+// passed through untouched so nothing gets compressed twice, and Vary:
+// Accept-Encoding is always set so caches keep the variants apart.
+func Gzip(minSize int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(c.GetHeader("Accept-Encoding")) {
+			c.Next()
+			return
+		}
+
+		bw := &bufferingWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = bw
+		c.Next()
+		c.Writer = bw.ResponseWriter
+
+		header := c.Writer.Header()
+		body := bw.buf.Bytes()
+		if header.Get("Content-Encoding") != "" || len(body) < minSize {
+			c.Writer.WriteHeader(bw.status)
+			c.Writer.Write(body)
+			return
+		}
+
+		header.Set("Content-Encoding", "gzip")
+		header.Del("Content-Length")
+		c.Writer.WriteHeader(bw.status)
+		gz := gzip.NewWriter(c.Writer)
+		if _, err := gz.Write(body); err == nil {
+			gz.Close()
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package ginmw
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func gzipRouter(minSize int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Gzip(minSize))
+	r.GET("/big", func(c *gin.Context) {
+		c.String(http.StatusOK, strings.Repeat("all work and no play ", 50))
+	})
+	r.GET("/tiny", func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+	r.GET("/encoded", func(c *gin.Context) {
+		c.Header("Content-Encoding", "br")
+		c.String(http.StatusOK, strings.Repeat("pre-compressed ", 50))
+	})
+	return r
+}
+
+func TestGzipCompressesLargeResponse(t *testing.T) {
+	r := gzipRouter(256)
+
+	req := httptest.NewRequest(http.MethodGet, "/big", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("Content-Encoding = %q, want gzip", got)
+	}
+	if got := w.Header().Get("Vary"); got != "Accept-Encoding" {
+		t.Fatalf("Vary = %q, want Accept-Encoding", got)
+	}
+
+	gz, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("gzip reader: %v", err)
+	}
+	raw, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("decompress: %v", err)
+	}
+	if !strings.HasPrefix(string(raw), "all work and no play") {
+		t.Fatalf("decompressed body = %q...", raw[:30])
+	}
+}
+
+func TestGzipSkipsClientsWithoutSupport(t *testing.T) {
+	r := gzipRouter(256)
+
+	req := httptest.NewRequest(http.MethodGet, "/big", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Fatalf("Content-Encoding = %q, want none", got)
+	}
+	// The response still varies on the header even when sent plain.
+	if got := w.Header().Get("Vary"); got != "Accept-Encoding" {
+		t.Fatalf("Vary = %q, want Accept-Encoding", got)
+	}
+	if !strings.HasPrefix(w.Body.String(), "all work and no play") {
+		t.Fatalf("body = %q...", w.Body.String()[:30])
+	}
+}
+
+func TestGzipLeavesTinyResponseAlone(t *testing.T) {
+	r := gzipRouter(256)
+
+	req := httptest.NewRequest(http.MethodGet, "/tiny", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Fatalf("Content-Encoding = %q, want none below minSize", got)
+	}
+	if w.Body.String() != "ok" {
+		t.Fatalf("body = %q", w.Body.String())
+	}
+}
+
+func TestGzipNeverDoubleCompresses(t *testing.T) {
+	r := gzipRouter(256)
+
+	req := httptest.NewRequest(http.MethodGet, "/encoded", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Encoding"); got != "br" {
+		t.Fatalf("Content-Encoding = %q, want the handler's br untouched", got)
+	}
+	if !strings.HasPrefix(w.Body.String(), "pre-compressed") {
+		t.Fatalf("body was altered: %q...", w.Body.String()[:30])
+	}
+}
@@ -0,0 +1,35 @@
+package ginmw
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Pagination bounds shared by every list endpoint.
+const (
+	DefaultPage     = 1
+	DefaultPageSize = 10
+	MaxPageSize     = 100
+)
+
+// ParsePagination reads the page and page_size query parameters, applying
+// defaults when absent or unparsable and clamping to sane bounds: page is at
+// least 1, page_size between 1 and MaxPageSize.
+func ParsePagination(c *gin.Context) (page, size int) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", ""))
+	if err != nil || page < 1 {
+		page = DefaultPage
+	}
+	size, err = strconv.Atoi(c.DefaultQuery("page_size", ""))
+	if err != nil {
+		size = DefaultPageSize
+	}
+	if size < 1 {
+		size = 1
+	}
+	if size > MaxPageSize {
+		size = MaxPageSize
+	}
+	return page, size
+}
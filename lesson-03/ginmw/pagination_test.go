@@ -0,0 +1,39 @@
+package ginmw
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestParsePagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		query    string
+		wantPage int
+		wantSize int
+	}{
+		{"valid", "?page=3&page_size=25", 3, 25},
+		{"missing", "", 1, 10},
+		{"negative", "?page=-2&page_size=-5", 1, 1},
+		{"zero", "?page=0&page_size=0", 1, 1},
+		{"oversized", "?page=2&page_size=500", 2, 100},
+		{"garbage", "?page=abc&page_size=xyz", 1, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/list"+tt.query, nil)
+
+			page, size := ParsePagination(c)
+			if page != tt.wantPage || size != tt.wantSize {
+				t.Fatalf("ParsePagination(%q) = (%d, %d), want (%d, %d)",
+					tt.query, page, size, tt.wantPage, tt.wantSize)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package ginmw
+
+import (
+	"sync"
+	"time"
+)
+
+// keyWindow tracks one key's request counts in the current and the previous
+// fixed window; the sliding estimate blends the two.
+type keyWindow struct {
+	start    time.Time // start of the current fixed window
+	current  int
+	previous int
+}
+
+// SlidingWindowLimiter allows at most limit requests per window and key,
+// using the sliding-window-counter approximation: the previous window's
+// count is weighted by how much of it still overlaps the sliding window, so
+// a burst right before a boundary keeps counting right after it — the
+// loophole a plain fixed-window counter leaves open.
+type SlidingWindowLimiter struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	keys   map[string]*keyWindow
+
+	now func() time.Time // stubbed in tests
+}
+
+// NewSlidingWindowLimiter allows limit requests per window for each key.
+func NewSlidingWindowLimiter(limit int, window time.Duration) *SlidingWindowLimiter {
+	return &SlidingWindowLimiter{
+		limit:  limit,
+		window: window,
+		keys:   make(map[string]*keyWindow),
+		now:    time.Now,
+	}
+}
+
+// Allow reports whether another request for key fits the budget, counting it
+// if so.
+func (l *SlidingWindowLimiter) Allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	windowStart := now.Truncate(l.window)
+
+	kw, ok := l.keys[key]
+	if !ok {
+		kw = &keyWindow{start: windowStart}
+		l.keys[key] = kw
+	}
+	switch {
+	case kw.start.Equal(windowStart):
+		// still in the same window
+	case windowStart.Sub(kw.start) == l.window:
+		kw.previous = kw.current
+		kw.current = 0
+		kw.start = windowStart
+	default:
+		// More than one full window has passed; nothing overlaps anymore.
+		kw.previous = 0
+		kw.current = 0
+		kw.start = windowStart
+	}
+
+	// Fraction of the previous window still inside the sliding window.
+	overlap := 1 - float64(now.Sub(windowStart))/float64(l.window)
+	estimate := float64(kw.previous)*overlap + float64(kw.current)
+
+	if estimate+1 > float64(l.limit) {
+		return false
+	}
+	kw.current++
+	return true
+}
@@ -0,0 +1,94 @@
+package ginmw
+
+import (
+	"testing"
+	"time"
+)
+
+// limiterAt returns a limiter whose clock the test controls, starting at a
+// window boundary.
+func limiterAt(limit int, window time.Duration) (*SlidingWindowLimiter, *time.Time) {
+	l := NewSlidingWindowLimiter(limit, window)
+	clock := time.Unix(0, 0).Truncate(window)
+	l.now = func() time.Time { return clock }
+	return l, &clock
+}
+
+func TestSlidingWindowBasicBudget(t *testing.T) {
+	l, _ := limiterAt(5, time.Minute)
+
+	for i := 0; i < 5; i++ {
+		if !l.Allow("alice") {
+			t.Fatalf("request %d should fit the budget", i+1)
+		}
+	}
+	if l.Allow("alice") {
+		t.Fatal("request over the budget should be rejected")
+	}
+	// Other keys have their own budget.
+	if !l.Allow("bob") {
+		t.Fatal("a different key must not share the budget")
+	}
+}
+
+func TestSlidingWindowRejectsBoundaryBurst(t *testing.T) {
+	// A fixed-window counter would allow 10 requests at 0:59 and another 10
+	// at 1:01 — 20 requests within two seconds. The sliding window must not.
+	l, clock := limiterAt(10, time.Minute)
+
+	*clock = clock.Add(59 * time.Second)
+	allowedBefore := 0
+	for i := 0; i < 10; i++ {
+		if l.Allow("burst") {
+			allowedBefore++
+		}
+	}
+	if allowedBefore != 10 {
+		t.Fatalf("allowed %d before the boundary, want 10", allowedBefore)
+	}
+
+	*clock = clock.Add(2 * time.Second) // 1:01, just past the boundary
+	allowedAfter := 0
+	for i := 0; i < 10; i++ {
+		if l.Allow("burst") {
+			allowedAfter++
+		}
+	}
+	// ~98% of the previous window still overlaps, so nearly nothing fits.
+	if allowedAfter > 1 {
+		t.Fatalf("allowed %d right after the boundary, want at most 1", allowedAfter)
+	}
+}
+
+func TestSlidingWindowRecoversGradually(t *testing.T) {
+	l, clock := limiterAt(10, time.Minute)
+
+	for i := 0; i < 10; i++ {
+		l.Allow("steady")
+	}
+	// Half a window later roughly half the budget is back.
+	*clock = clock.Add(90 * time.Second)
+	allowed := 0
+	for i := 0; i < 10; i++ {
+		if l.Allow("steady") {
+			allowed++
+		}
+	}
+	if allowed < 4 || allowed > 6 {
+		t.Fatalf("allowed %d halfway through the next window, want about 5", allowed)
+	}
+}
+
+func TestSlidingWindowIdleKeyResets(t *testing.T) {
+	l, clock := limiterAt(3, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		l.Allow("idle")
+	}
+	*clock = clock.Add(5 * time.Minute)
+	for i := 0; i < 3; i++ {
+		if !l.Allow("idle") {
+			t.Fatalf("request %d after a long idle period should be allowed", i+1)
+		}
+	}
+}
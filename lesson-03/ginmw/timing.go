@@ -0,0 +1,53 @@
+package ginmw
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// timingWriter injects the timing headers just before the first byte of the
+// response goes out, since they cannot be added once the header is written.
+type timingWriter struct {
+	gin.ResponseWriter
+	start    time.Time
+	injected bool
+}
+
+func (w *timingWriter) inject() {
+	if w.injected {
+		return
+	}
+	w.injected = true
+	ms := float64(time.Since(w.start).Microseconds()) / 1000
+	w.Header().Set("Server-Timing", fmt.Sprintf("app;dur=%.1f", ms))
+	w.Header().Set("X-Response-Time-Ms", strconv.FormatFloat(ms, 'f', 1, 64))
+}
+
+func (w *timingWriter) WriteHeader(code int) {
+	w.inject()
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *timingWriter) Write(p []byte) (int, error) {
+	w.inject()
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *timingWriter) WriteString(s string) (int, error) {
+	w.inject()
+	return w.ResponseWriter.WriteString(s)
+}
+
+// ServerTiming reports how long the rest of the handler chain took via a
+// Server-Timing header (app;dur=<ms>) plus a plain X-Response-Time-Ms. The
+// clock starts before c.Next() and stops when the response starts flushing,
+// covering every later middleware and the handler itself.
+func ServerTiming() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer = &timingWriter{ResponseWriter: c.Writer, start: time.Now()}
+		c.Next()
+	}
+}
@@ -0,0 +1,46 @@
+package ginmw
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestServerTimingHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(ServerTiming())
+	r.GET("/slow", func(c *gin.Context) {
+		time.Sleep(10 * time.Millisecond)
+		c.String(http.StatusOK, "done")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+	st := w.Header().Get("Server-Timing")
+	if !strings.HasPrefix(st, "app;dur=") {
+		t.Fatalf("Server-Timing = %q, want app;dur=...", st)
+	}
+	dur, err := strconv.ParseFloat(strings.TrimPrefix(st, "app;dur="), 64)
+	if err != nil {
+		t.Fatalf("parse Server-Timing duration: %v", err)
+	}
+	if dur < 10 {
+		t.Errorf("Server-Timing duration = %v ms, want >= 10", dur)
+	}
+
+	xrt := w.Header().Get("X-Response-Time-Ms")
+	ms, err := strconv.ParseFloat(xrt, 64)
+	if err != nil {
+		t.Fatalf("parse X-Response-Time-Ms %q: %v", xrt, err)
+	}
+	if ms <= 0 {
+		t.Errorf("X-Response-Time-Ms = %v, want > 0", ms)
+	}
+}
@@ -0,0 +1,87 @@
+package ginmw
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Gin context keys under which Trace stores the identifiers.
+const (
+	TraceIDKey = "traceID"
+	SpanIDKey  = "spanID"
+)
+
+// randomHex returns n random bytes as lowercase hex.
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// validHex reports whether s is n lowercase hex characters and not all zero,
+// as the W3C Trace Context spec requires for trace and span IDs.
+func validHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	allZero := true
+	for _, r := range s {
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'f') {
+			return false
+		}
+		if r != '0' {
+			allZero = false
+		}
+	}
+	return !allZero
+}
+
+// parseTraceparent extracts the trace ID and parent span ID from a W3C
+// traceparent header (version-traceid-spanid-flags). It rejects malformed
+// headers so a broken upstream value never propagates.
+func parseTraceparent(header string) (traceID, parentSpanID string, ok bool) {
+	parts := strings.Split(header, "-")
+	if len(parts) != 4 {
+		return "", "", false
+	}
+	if parts[0] != "00" || !validHex(parts[1], 32) || !validHex(parts[2], 16) || !validHex(parts[3], 2) {
+		return "", "", false
+	}
+	return parts[1], parts[2], true
+}
+
+// Trace implements W3C Trace Context propagation: an incoming traceparent
+// header continues the caller's trace, a missing or malformed one starts a
+// fresh trace. Each request gets its own span ID. The IDs land in the Gin
+// context (TraceIDKey, SpanIDKey), in the traceparent response header, and —
+// when logf is non-nil — in one log line per request.
+func Trace(logf func(format string, args ...any)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		traceID, parentSpanID, ok := parseTraceparent(c.GetHeader("traceparent"))
+		if !ok {
+			traceID = randomHex(16)
+		}
+		spanID := randomHex(8)
+
+		c.Set(TraceIDKey, traceID)
+		c.Set(SpanIDKey, spanID)
+		c.Header("traceparent", fmt.Sprintf("00-%s-%s-01", traceID, spanID))
+
+		c.Next()
+
+		if logf != nil {
+			logf("trace=%s span=%s parent=%s %s %s -> %d",
+				traceID, spanID, parentSpanID, c.Request.Method, c.Request.URL.Path, c.Writer.Status())
+		}
+	}
+}
+
+// TraceID returns the trace ID Trace stored for this request.
+func TraceID(c *gin.Context) string { return c.GetString(TraceIDKey) }
+
+// SpanID returns the span ID Trace stored for this request.
+func SpanID(c *gin.Context) string { return c.GetString(SpanIDKey) }
@@ -0,0 +1,102 @@
+package ginmw
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+var traceparentRe = regexp.MustCompile(`^00-[0-9a-f]{32}-[0-9a-f]{16}-01$`)
+
+// traceRequest runs one request through the Trace middleware and returns the
+// recorder plus the trace/span IDs the handler saw.
+func traceRequest(t *testing.T, incoming string, logf func(string, ...any)) (*httptest.ResponseRecorder, string, string) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Trace(logf))
+
+	var traceID, spanID string
+	r.GET("/ping", func(c *gin.Context) {
+		traceID = TraceID(c)
+		spanID = SpanID(c)
+		c.String(http.StatusOK, "pong")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	if incoming != "" {
+		req.Header.Set("traceparent", incoming)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w, traceID, spanID
+}
+
+func TestTraceContinuesIncomingTrace(t *testing.T) {
+	incomingTrace := "4bf92f3577b34da6a3ce929d0e0e4736"
+	w, traceID, spanID := traceRequest(t,
+		fmt.Sprintf("00-%s-00f067aa0ba902b7-01", incomingTrace), nil)
+
+	if traceID != incomingTrace {
+		t.Fatalf("trace ID = %q, want incoming %q", traceID, incomingTrace)
+	}
+	if spanID == "00f067aa0ba902b7" {
+		t.Fatal("span ID must be fresh, not the caller's span")
+	}
+	header := w.Header().Get("traceparent")
+	if !traceparentRe.MatchString(header) {
+		t.Fatalf("response traceparent = %q, not W3C formatted", header)
+	}
+	if !strings.Contains(header, incomingTrace) {
+		t.Fatalf("response traceparent %q lost the incoming trace ID", header)
+	}
+}
+
+func TestTraceGeneratesWhenMissing(t *testing.T) {
+	w, traceID, spanID := traceRequest(t, "", nil)
+
+	if len(traceID) != 32 || len(spanID) != 16 {
+		t.Fatalf("generated IDs trace=%q span=%q, want 32 and 16 hex chars", traceID, spanID)
+	}
+	header := w.Header().Get("traceparent")
+	if !traceparentRe.MatchString(header) {
+		t.Fatalf("response traceparent = %q, not W3C formatted", header)
+	}
+}
+
+func TestTraceRegeneratesWhenMalformed(t *testing.T) {
+	malformed := []string{
+		"not-a-traceparent",
+		"00-zzzz-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01", // all-zero trace ID
+		"99-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", // unknown version
+	}
+	for _, in := range malformed {
+		w, traceID, _ := traceRequest(t, in, nil)
+		if strings.Contains(in, traceID) && len(traceID) == 32 {
+			t.Fatalf("malformed header %q was propagated", in)
+		}
+		if !traceparentRe.MatchString(w.Header().Get("traceparent")) {
+			t.Fatalf("response traceparent for %q = %q, not regenerated",
+				in, w.Header().Get("traceparent"))
+		}
+	}
+}
+
+func TestTraceLogsIdentifiers(t *testing.T) {
+	var logged string
+	logf := func(format string, args ...any) { logged = fmt.Sprintf(format, args...) }
+
+	_, traceID, spanID := traceRequest(t, "", logf)
+
+	for _, want := range []string{"trace=" + traceID, "span=" + spanID, "/ping", "200"} {
+		if !strings.Contains(logged, want) {
+			t.Fatalf("log line %q missing %q", logged, want)
+		}
+	}
+}
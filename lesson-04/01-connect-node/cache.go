@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// blockFetcher loads a block by tag; getBlockByTag curried with a client
+// satisfies it.
+type blockFetcher func(ctx context.Context, tag string) (*blockInfo, error)
+
+type cacheEntry struct {
+	info     *blockInfo
+	storedAt time.Time
+}
+
+// HeaderCache memoizes block lookups by tag with a bounded size (LRU
+// eviction) and a TTL, so repeated queries for the same block skip the RPC
+// round trip. Mutable tags like "latest" benefit from the TTL: they go stale
+// and are refetched after it passes.
+type HeaderCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	fetch   blockFetcher
+	entries map[string]cacheEntry
+	order   []string // LRU order, most recently used last
+
+	now func() time.Time // stubbed in tests
+}
+
+// NewHeaderCache returns a cache of at most size blocks, each valid for ttl.
+func NewHeaderCache(size int, ttl time.Duration, fetch blockFetcher) *HeaderCache {
+	return &HeaderCache{
+		size:    size,
+		ttl:     ttl,
+		fetch:   fetch,
+		entries: make(map[string]cacheEntry),
+		now:     time.Now,
+	}
+}
+
+// touch moves tag to the most-recently-used position.
+func (c *HeaderCache) touch(tag string) {
+	for i, t := range c.order {
+		if t == tag {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+	c.order = append(c.order, tag)
+}
+
+// GetBlock returns the cached block for tag, fetching (and caching) it when
+// absent or expired.
+func (c *HeaderCache) GetBlock(ctx context.Context, tag string) (*blockInfo, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[tag]
+	if ok && c.now().Sub(entry.storedAt) <= c.ttl {
+		c.touch(tag)
+		c.mu.Unlock()
+		return entry.info, nil
+	}
+	c.mu.Unlock()
+
+	info, err := c.fetch(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.entries[tag]; !exists && len(c.entries) >= c.size {
+		oldest := c.order[0]
+		c.order = c.order[1:]
+		delete(c.entries, oldest)
+	}
+	c.entries[tag] = cacheEntry{info: info, storedAt: c.now()}
+	c.touch(tag)
+	return info, nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// countingFetcher records how often each tag is actually fetched.
+type countingFetcher struct {
+	calls map[string]int
+}
+
+func (f *countingFetcher) fetch(ctx context.Context, tag string) (*blockInfo, error) {
+	f.calls[tag]++
+	return &blockInfo{Number: tag}, nil
+}
+
+func newCacheForTest(size int, ttl time.Duration) (*HeaderCache, *countingFetcher, *time.Time) {
+	fetcher := &countingFetcher{calls: make(map[string]int)}
+	cache := NewHeaderCache(size, ttl, fetcher.fetch)
+	clock := time.Now()
+	cache.now = func() time.Time { return clock }
+	return cache, fetcher, &clock
+}
+
+func TestHeaderCacheHit(t *testing.T) {
+	cache, fetcher, _ := newCacheForTest(4, time.Minute)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		info, err := cache.GetBlock(ctx, "0x10")
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		if info.Number != "0x10" {
+			t.Fatalf("number = %q", info.Number)
+		}
+	}
+	if fetcher.calls["0x10"] != 1 {
+		t.Fatalf("fetched %d times, want 1", fetcher.calls["0x10"])
+	}
+}
+
+func TestHeaderCacheTTLExpiry(t *testing.T) {
+	cache, fetcher, clock := newCacheForTest(4, time.Minute)
+	ctx := context.Background()
+
+	if _, err := cache.GetBlock(ctx, "latest"); err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	*clock = clock.Add(2 * time.Minute)
+	if _, err := cache.GetBlock(ctx, "latest"); err != nil {
+		t.Fatalf("get after expiry: %v", err)
+	}
+	if fetcher.calls["latest"] != 2 {
+		t.Fatalf("fetched %d times, want 2 (TTL refetch)", fetcher.calls["latest"])
+	}
+}
+
+func TestHeaderCacheLRUEviction(t *testing.T) {
+	cache, fetcher, _ := newCacheForTest(2, time.Minute)
+	ctx := context.Background()
+
+	cache.GetBlock(ctx, "0x1")
+	cache.GetBlock(ctx, "0x2")
+	cache.GetBlock(ctx, "0x1") // refresh 0x1, making 0x2 the LRU entry
+	cache.GetBlock(ctx, "0x3") // evicts 0x2
+
+	cache.GetBlock(ctx, "0x1")
+	if fetcher.calls["0x1"] != 1 {
+		t.Fatalf("0x1 fetched %d times, want 1 (still cached)", fetcher.calls["0x1"])
+	}
+	cache.GetBlock(ctx, "0x2")
+	if fetcher.calls["0x2"] != 2 {
+		t.Fatalf("0x2 fetched %d times, want 2 (evicted then refetched)", fetcher.calls["0x2"])
+	}
+}
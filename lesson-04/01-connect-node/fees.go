@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// feeReader is the slice of ethclient the fee estimate needs.
+type feeReader interface {
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+}
+
+// FeeEstimate summarizes current gas pricing. PriorityFee and MaxFee are only
+// set on EIP-1559 chains, i.e. when the header carries a base fee.
+type FeeEstimate struct {
+	GasPrice    *big.Int
+	BaseFee     *big.Int
+	PriorityFee *big.Int
+	MaxFee      *big.Int
+}
+
+// minPriorityFee is the floor tip (1 gwei) used when the suggested gas price
+// does not exceed the base fee.
+var minPriorityFee = big.NewInt(1_000_000_000)
+
+// estimateFees combines the node's suggested gas price with the latest
+// header's base fee into a fee estimate. The max fee follows the usual
+// 2*baseFee+tip rule, which survives six consecutive fully-loaded blocks.
+func estimateFees(ctx context.Context, client feeReader, header *types.Header) (FeeEstimate, error) {
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	if err != nil {
+		return FeeEstimate{}, fmt.Errorf("suggest gas price: %w", err)
+	}
+
+	est := FeeEstimate{GasPrice: gasPrice}
+	if header == nil || header.BaseFee == nil {
+		return est, nil
+	}
+
+	est.BaseFee = header.BaseFee
+	est.PriorityFee = new(big.Int).Sub(gasPrice, header.BaseFee)
+	if est.PriorityFee.Cmp(minPriorityFee) < 0 {
+		est.PriorityFee = new(big.Int).Set(minPriorityFee)
+	}
+	est.MaxFee = new(big.Int).Mul(header.BaseFee, big.NewInt(2))
+	est.MaxFee.Add(est.MaxFee, est.PriorityFee)
+	return est, nil
+}
+
+func printFeeEstimate(est FeeEstimate) {
+	fmt.Println("=== Fee Estimate ===")
+	fmt.Printf("  gas price:    %s wei\n", est.GasPrice)
+	if est.BaseFee == nil {
+		fmt.Println("  (no base fee; legacy pricing)")
+		return
+	}
+	fmt.Printf("  base fee:     %s wei\n", est.BaseFee)
+	fmt.Printf("  priority fee: %s wei\n", est.PriorityFee)
+	fmt.Printf("  max fee:      %s wei\n", est.MaxFee)
+}
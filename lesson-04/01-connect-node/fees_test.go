@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// fakeFeeReader returns a fixed suggested gas price.
+type fakeFeeReader struct {
+	gasPrice *big.Int
+	err      error
+}
+
+func (f *fakeFeeReader) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return f.gasPrice, f.err
+}
+
+func gwei(n int64) *big.Int { return big.NewInt(n * 1_000_000_000) }
+
+func TestEstimateFeesWithBaseFee(t *testing.T) {
+	client := &fakeFeeReader{gasPrice: gwei(32)}
+	header := &types.Header{BaseFee: gwei(30)}
+
+	est, err := estimateFees(context.Background(), client, header)
+	if err != nil {
+		t.Fatalf("estimate: %v", err)
+	}
+	if est.PriorityFee.Cmp(gwei(2)) != 0 {
+		t.Errorf("priority fee = %s, want %s", est.PriorityFee, gwei(2))
+	}
+	// max fee = 2*baseFee + priority = 60 + 2 gwei.
+	if est.MaxFee.Cmp(gwei(62)) != 0 {
+		t.Errorf("max fee = %s, want %s", est.MaxFee, gwei(62))
+	}
+}
+
+func TestEstimateFeesPriorityFloor(t *testing.T) {
+	// Suggested price below the base fee: the tip falls back to 1 gwei.
+	client := &fakeFeeReader{gasPrice: gwei(29)}
+	header := &types.Header{BaseFee: gwei(30)}
+
+	est, err := estimateFees(context.Background(), client, header)
+	if err != nil {
+		t.Fatalf("estimate: %v", err)
+	}
+	if est.PriorityFee.Cmp(gwei(1)) != 0 {
+		t.Errorf("priority fee = %s, want %s", est.PriorityFee, gwei(1))
+	}
+}
+
+func TestEstimateFeesLegacyChain(t *testing.T) {
+	client := &fakeFeeReader{gasPrice: gwei(5)}
+
+	est, err := estimateFees(context.Background(), client, &types.Header{})
+	if err != nil {
+		t.Fatalf("estimate: %v", err)
+	}
+	if est.GasPrice.Cmp(gwei(5)) != 0 || est.MaxFee != nil {
+		t.Errorf("legacy estimate = %+v", est)
+	}
+}
+
+func TestEstimateFeesError(t *testing.T) {
+	boom := errors.New("rpc down")
+	if _, err := estimateFees(context.Background(), &fakeFeeReader{err: boom}, nil); !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want wrapped boom", err)
+	}
+}
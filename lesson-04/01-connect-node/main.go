@@ -0,0 +1,153 @@
+// Lesson 04-01: connect to an Ethereum node over JSON-RPC and inspect blocks.
+//
+// Run with a node URL, e.g.:
+//
+//	go run . -url https://ethereum-rpc.publicnode.com
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/lannisite110/hello_world/lesson-04/logging"
+)
+
+// Sentinel errors returned by getBlockByTag so callers can branch with
+// errors.Is instead of matching message strings.
+var (
+	// ErrBlockNotFound is returned when the node has no block for the
+	// requested tag (the RPC result is null or empty).
+	ErrBlockNotFound = errors.New("block not found")
+
+	// ErrUnsupportedTag is returned when the tag is neither a known named
+	// tag nor a hex-encoded block number.
+	ErrUnsupportedTag = errors.New("unsupported block tag")
+)
+
+// namedTags are the block tags accepted by eth_getBlockByNumber.
+var namedTags = map[string]bool{
+	"latest":    true,
+	"earliest":  true,
+	"pending":   true,
+	"safe":      true,
+	"finalized": true,
+}
+
+var hexNumberRe = regexp.MustCompile(`^0x[0-9a-fA-F]+$`)
+
+// blockInfo holds the handful of block fields the example prints.
+type blockInfo struct {
+	Number     string `json:"number"`
+	Hash       string `json:"hash"`
+	ParentHash string `json:"parentHash"`
+	Timestamp  string `json:"timestamp"`
+	GasUsed    string `json:"gasUsed"`
+	GasLimit   string `json:"gasLimit"`
+	BaseFee    string `json:"baseFeePerGas"`
+	Miner      string `json:"miner"`
+}
+
+// getBlockByTag fetches a block header by tag ("latest", "pending", ... or a
+// hex number like "0x10"). A missing block is reported as ErrBlockNotFound
+// and a malformed tag as ErrUnsupportedTag, both wrapped so errors.Is works.
+func getBlockByTag(ctx context.Context, client *rpc.Client, tag string) (*blockInfo, error) {
+	if !namedTags[tag] && !hexNumberRe.MatchString(tag) {
+		return nil, fmt.Errorf("tag %q: %w", tag, ErrUnsupportedTag)
+	}
+
+	var raw json.RawMessage
+	if err := client.CallContext(ctx, &raw, "eth_getBlockByNumber", tag, false); err != nil {
+		return nil, fmt.Errorf("eth_getBlockByNumber %s: %w", tag, err)
+	}
+	return parseRawBlock(tag, raw)
+}
+
+// parseRawBlock decodes the raw RPC result for a block. The JSON-RPC spec
+// returns null for unknown blocks, which surfaces here as a nil, empty, or
+// literal "null" raw message; all of those map to ErrBlockNotFound.
+func parseRawBlock(tag string, raw json.RawMessage) (*blockInfo, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, fmt.Errorf("tag %q: %w", tag, ErrBlockNotFound)
+	}
+	var info blockInfo
+	if err := json.Unmarshal(raw, &info); err != nil {
+		return nil, fmt.Errorf("decode block %s: %w", tag, err)
+	}
+	return &info, nil
+}
+
+func printBlock(info *blockInfo) {
+	fmt.Println("=== Block ===")
+	fmt.Printf("  number:     %s\n", info.Number)
+	fmt.Printf("  hash:       %s\n", info.Hash)
+	fmt.Printf("  parent:     %s\n", info.ParentHash)
+	fmt.Printf("  timestamp:  %s\n", info.Timestamp)
+	fmt.Printf("  gas used:   %s\n", info.GasUsed)
+	fmt.Printf("  gas limit:  %s\n", info.GasLimit)
+	fmt.Printf("  base fee:   %s\n", info.BaseFee)
+	fmt.Printf("  miner:      %s\n", info.Miner)
+}
+
+func main() {
+	url := flag.String("url", "http://localhost:8545", "node RPC URL")
+	tag := flag.String("tag", "latest", "block tag or hex number")
+	flag.Parse()
+
+	logger := logging.Setup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	client, err := rpc.DialContext(ctx, *url)
+	if err != nil {
+		logger.Error("dial failed", "url", *url, "err", err)
+		os.Exit(1)
+	}
+	defer client.Close()
+
+	var chainID string
+	if err := client.CallContext(ctx, &chainID, "eth_chainId"); err != nil {
+		logger.Error("eth_chainId failed", "err", err)
+		os.Exit(1)
+	}
+	logger.Info("connected", "url", *url, "chain_id", chainID)
+
+	cache := NewHeaderCache(64, 15*time.Second, func(ctx context.Context, tag string) (*blockInfo, error) {
+		return getBlockByTag(ctx, client, tag)
+	})
+	info, err := cache.GetBlock(ctx, *tag)
+	switch {
+	case errors.Is(err, ErrBlockNotFound):
+		logger.Error("no block for tag", "tag", *tag)
+		os.Exit(1)
+	case errors.Is(err, ErrUnsupportedTag):
+		logger.Error("bad tag, want latest/earliest/pending/safe/finalized or 0x...", "tag", *tag)
+		os.Exit(1)
+	case err != nil:
+		logger.Error("get block failed", "tag", *tag, "err", err)
+		os.Exit(1)
+	}
+	printBlock(info)
+
+	eth := ethclient.NewClient(client)
+	header, err := eth.HeaderByNumber(ctx, nil)
+	if err != nil {
+		logger.Error("latest header failed", "err", err)
+		os.Exit(1)
+	}
+	est, err := estimateFees(ctx, eth, header)
+	if err != nil {
+		logger.Error("estimate fees failed", "block", header.Number, "err", err)
+		os.Exit(1)
+	}
+	printFeeEstimate(est)
+}
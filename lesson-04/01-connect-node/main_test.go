@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseRawBlockNotFound(t *testing.T) {
+	cases := []struct {
+		name string
+		raw  json.RawMessage
+	}{
+		{"nil raw", nil},
+		{"empty raw", json.RawMessage("")},
+		{"null literal", json.RawMessage("null")},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseRawBlock("latest", tc.raw)
+			if !errors.Is(err, ErrBlockNotFound) {
+				t.Fatalf("got %v, want ErrBlockNotFound", err)
+			}
+		})
+	}
+}
+
+func TestParseRawBlockOK(t *testing.T) {
+	raw := json.RawMessage(`{"number":"0x10","hash":"0xabc","gasUsed":"0x5208"}`)
+	info, err := parseRawBlock("0x10", raw)
+	if err != nil {
+		t.Fatalf("parseRawBlock: %v", err)
+	}
+	if info.Number != "0x10" || info.Hash != "0xabc" || info.GasUsed != "0x5208" {
+		t.Fatalf("unexpected block info: %+v", info)
+	}
+}
+
+func TestGetBlockByTagUnsupported(t *testing.T) {
+	for _, tag := range []string{"", "newest", "0x", "12345", "0xzz"} {
+		_, err := getBlockByTag(context.Background(), nil, tag)
+		if !errors.Is(err, ErrUnsupportedTag) {
+			t.Fatalf("tag %q: got %v, want ErrUnsupportedTag", tag, err)
+		}
+	}
+}
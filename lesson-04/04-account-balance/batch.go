@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// batchCaller is the slice of rpc.Client the batch fetcher needs.
+type batchCaller interface {
+	BatchCallContext(ctx context.Context, b []rpc.BatchElem) error
+}
+
+// BalancesAt fetches the balances of many accounts in one JSON-RPC batch
+// round trip instead of one eth_getBalance call per address. A nil block
+// queries the latest state.
+func BalancesAt(ctx context.Context, client batchCaller, addrs []common.Address, block *big.Int) (map[common.Address]*big.Int, error) {
+	blockArg := "latest"
+	if block != nil {
+		blockArg = hexutil.EncodeBig(block)
+	}
+
+	results := make([]hexutil.Big, len(addrs))
+	batch := make([]rpc.BatchElem, len(addrs))
+	for i, addr := range addrs {
+		batch[i] = rpc.BatchElem{
+			Method: "eth_getBalance",
+			Args:   []any{addr, blockArg},
+			Result: &results[i],
+		}
+	}
+
+	if err := client.BatchCallContext(ctx, batch); err != nil {
+		return nil, fmt.Errorf("batch call: %w", err)
+	}
+
+	balances := make(map[common.Address]*big.Int, len(addrs))
+	for i, elem := range batch {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("balance of %s: %w", addrs[i], elem.Error)
+		}
+		balances[addrs[i]] = (*big.Int)(&results[i])
+	}
+	return balances, nil
+}
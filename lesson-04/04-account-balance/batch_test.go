@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// fakeBatchCaller answers eth_getBalance batches from a fixture map.
+type fakeBatchCaller struct {
+	balances map[common.Address]*big.Int
+	err      error
+}
+
+func (f *fakeBatchCaller) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	if f.err != nil {
+		return f.err
+	}
+	for i := range batch {
+		addr := batch[i].Args[0].(common.Address)
+		bal, ok := f.balances[addr]
+		if !ok {
+			batch[i].Error = errors.New("unknown account")
+			continue
+		}
+		*batch[i].Result.(*hexutil.Big) = (hexutil.Big)(*bal)
+	}
+	return nil
+}
+
+func TestBalancesAt(t *testing.T) {
+	a := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	b := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	client := &fakeBatchCaller{balances: map[common.Address]*big.Int{
+		a: big.NewInt(1000),
+		b: big.NewInt(2500),
+	}}
+
+	// Request in both orders; the map must come back identical.
+	for _, addrs := range [][]common.Address{{a, b}, {b, a}} {
+		got, err := BalancesAt(context.Background(), client, addrs, nil)
+		if err != nil {
+			t.Fatalf("BalancesAt: %v", err)
+		}
+		if len(got) != 2 {
+			t.Fatalf("got %d balances, want 2", len(got))
+		}
+		if got[a].Cmp(big.NewInt(1000)) != 0 || got[b].Cmp(big.NewInt(2500)) != 0 {
+			t.Fatalf("balances = %v", got)
+		}
+	}
+}
+
+func TestBalancesAtElementError(t *testing.T) {
+	client := &fakeBatchCaller{balances: map[common.Address]*big.Int{}}
+	_, err := BalancesAt(context.Background(), client,
+		[]common.Address{common.HexToAddress("0x3")}, nil)
+	if err == nil {
+		t.Fatal("expected error for unknown account")
+	}
+}
+
+func TestBalancesAtBatchError(t *testing.T) {
+	boom := errors.New("rpc down")
+	_, err := BalancesAt(context.Background(), &fakeBatchCaller{err: boom},
+		[]common.Address{common.HexToAddress("0x4")}, big.NewInt(1))
+	if !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want wrapped boom", err)
+	}
+}
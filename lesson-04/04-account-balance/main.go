@@ -0,0 +1,56 @@
+// Lesson 04-04: read an account balance and optionally watch it for changes.
+//
+//	go run . -url https://ethereum-rpc.publicnode.com -addr 0x... [-watch]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// weiPerEth converts wei to a human-readable ether string.
+func weiToEth(wei *big.Int) string {
+	eth := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(1e18))
+	return eth.Text('f', 6)
+}
+
+func main() {
+	url := flag.String("url", "http://localhost:8545", "node RPC URL")
+	addrStr := flag.String("addr", "", "account address")
+	watch := flag.Bool("watch", false, "keep polling and report balance changes")
+	interval := flag.Duration("interval", 12*time.Second, "poll interval in watch mode")
+	flag.Parse()
+
+	if *addrStr == "" {
+		log.Fatal("missing -addr")
+	}
+	addr := common.HexToAddress(*addrStr)
+
+	ctx := context.Background()
+	client, err := ethclient.DialContext(ctx, *url)
+	if err != nil {
+		log.Fatalf("dial %s: %v", *url, err)
+	}
+	defer client.Close()
+
+	balance, err := client.BalanceAt(ctx, addr, nil)
+	if err != nil {
+		log.Fatalf("balance of %s: %v", addr, err)
+	}
+	fmt.Printf("%s: %s ETH (%s wei)\n", addr, weiToEth(balance), balance)
+
+	if !*watch {
+		return
+	}
+	for change := range WatchBalance(ctx, client, addr, *interval) {
+		fmt.Printf("block %d: %s ETH -> %s ETH\n",
+			change.Block, weiToEth(change.Old), weiToEth(change.New))
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BalanceChange reports one observed balance transition.
+type BalanceChange struct {
+	Old   *big.Int
+	New   *big.Int
+	Block uint64
+}
+
+// watchClient is the part of ethclient WatchBalance needs, kept narrow so
+// tests can substitute a fake.
+type watchClient interface {
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// WatchBalance polls the balance of addr every interval and emits a
+// BalanceChange whenever the reading differs from the previous one. The first
+// reading only establishes the baseline. The channel is closed when ctx is
+// cancelled.
+func WatchBalance(ctx context.Context, client watchClient, addr common.Address, interval time.Duration) <-chan BalanceChange {
+	out := make(chan BalanceChange)
+	go func() {
+		defer close(out)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var prev *big.Int
+		var block uint64
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			balance, err := client.BalanceAt(ctx, addr, nil)
+			if err != nil {
+				log.Printf("watch balance: %v", err)
+				continue
+			}
+			if n, err := client.BlockNumber(ctx); err == nil {
+				block = n
+			}
+			if prev != nil && prev.Cmp(balance) != 0 {
+				change := BalanceChange{Old: prev, New: balance, Block: block}
+				select {
+				case out <- change:
+				case <-ctx.Done():
+					return
+				}
+			}
+			prev = balance
+		}
+	}()
+	return out
+}
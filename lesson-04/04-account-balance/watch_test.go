@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// steppedClient returns 100 wei for the first two polls and 250 afterwards.
+type steppedClient struct {
+	calls atomic.Int64
+}
+
+func (c *steppedClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	n := c.calls.Add(1)
+	if n < 3 {
+		return big.NewInt(100), nil
+	}
+	return big.NewInt(250), nil
+}
+
+func (c *steppedClient) BlockNumber(ctx context.Context) (uint64, error) { return 42, nil }
+
+func TestWatchBalanceEmitsOnChange(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := WatchBalance(ctx, &steppedClient{}, common.Address{}, time.Millisecond)
+
+	var change BalanceChange
+	select {
+	case change = <-ch:
+	case <-time.After(2 * time.Second):
+		t.Fatal("no balance change emitted")
+	}
+	if change.Old.Cmp(big.NewInt(100)) != 0 || change.New.Cmp(big.NewInt(250)) != 0 {
+		t.Fatalf("change = %s -> %s, want 100 -> 250", change.Old, change.New)
+	}
+	if change.Block != 42 {
+		t.Fatalf("block = %d, want 42", change.Block)
+	}
+
+	// The balance stays at 250 now, so no further emission; cancelling must
+	// close the channel without another value.
+	select {
+	case extra, ok := <-ch:
+		if ok {
+			t.Fatalf("unexpected second emission: %+v", extra)
+		}
+	case <-time.After(50 * time.Millisecond):
+	}
+	cancel()
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Fatal("expected channel close after cancel")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+}
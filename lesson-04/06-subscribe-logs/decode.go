@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// DecodedEvent is one contract event in a machine-readable shape.
+type DecodedEvent struct {
+	Name    string         `json:"event"`
+	Block   uint64         `json:"block"`
+	TxHash  string         `json:"tx_hash"`
+	Indexed map[string]any `json:"indexed"`
+	Data    map[string]any `json:"data"`
+}
+
+// renderValue converts go-ethereum decode results into JSON-friendly values:
+// addresses and hashes as hex strings, big integers as decimal strings.
+func renderValue(v any) any {
+	switch val := v.(type) {
+	case common.Address:
+		return val.Hex()
+	case common.Hash:
+		return val.Hex()
+	case *big.Int:
+		return val.String()
+	default:
+		return val
+	}
+}
+
+// decodeEvent decodes lg against contractABI into a DecodedEvent, splitting
+// indexed parameters (from topics) and non-indexed ones (from data).
+func decodeEvent(lg types.Log, contractABI abi.ABI) (DecodedEvent, error) {
+	if len(lg.Topics) == 0 {
+		return DecodedEvent{}, fmt.Errorf("log has no topics")
+	}
+	event, err := contractABI.EventByID(lg.Topics[0])
+	if err != nil {
+		return DecodedEvent{}, fmt.Errorf("unknown event topic %s: %w", lg.Topics[0], err)
+	}
+
+	var indexedArgs abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexedArgs = append(indexedArgs, arg)
+		}
+	}
+
+	indexed := make(map[string]any)
+	if err := abi.ParseTopicsIntoMap(indexed, indexedArgs, lg.Topics[1:]); err != nil {
+		return DecodedEvent{}, fmt.Errorf("parse topics of %s: %w", event.Name, err)
+	}
+	data := make(map[string]any)
+	if len(lg.Data) > 0 {
+		if err := contractABI.UnpackIntoMap(data, event.Name, lg.Data); err != nil {
+			return DecodedEvent{}, fmt.Errorf("unpack data of %s: %w", event.Name, err)
+		}
+	}
+	for k, v := range indexed {
+		indexed[k] = renderValue(v)
+	}
+	for k, v := range data {
+		data[k] = renderValue(v)
+	}
+
+	return DecodedEvent{
+		Name:    event.Name,
+		Block:   lg.BlockNumber,
+		TxHash:  lg.TxHash.Hex(),
+		Indexed: indexed,
+		Data:    data,
+	}, nil
+}
+
+// formatEventJSON renders one event as a single JSON line.
+func formatEventJSON(lg types.Log, contractABI abi.ABI) (string, error) {
+	event, err := decodeEvent(lg, contractABI)
+	if err != nil {
+		return "", err
+	}
+	out, err := json.Marshal(event)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
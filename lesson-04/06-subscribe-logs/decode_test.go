@@ -0,0 +1,47 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/lannisite110/hello_world/lesson-04/erc20"
+)
+
+func TestDecodeEventTransfer(t *testing.T) {
+	from := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	to := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	lg := types.Log{
+		BlockNumber: 777,
+		TxHash:      common.HexToHash("0xdead"),
+		Topics: []common.Hash{
+			erc20.TransferTopic(),
+			common.BytesToHash(from.Bytes()),
+			common.BytesToHash(to.Bytes()),
+		},
+		Data: common.BigToHash(big.NewInt(123456)).Bytes(),
+	}
+
+	event, err := decodeEvent(lg, erc20.ParsedABI())
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if event.Name != "Transfer" || event.Block != 777 {
+		t.Fatalf("event = %+v", event)
+	}
+	if event.Indexed["from"] != from.Hex() || event.Indexed["to"] != to.Hex() {
+		t.Fatalf("indexed = %v", event.Indexed)
+	}
+	if event.Data["value"] != "123456" {
+		t.Fatalf("data = %v", event.Data)
+	}
+}
+
+func TestDecodeEventUnknownTopic(t *testing.T) {
+	lg := types.Log{Topics: []common.Hash{common.HexToHash("0xabc")}}
+	if _, err := decodeEvent(lg, erc20.ParsedABI()); err == nil {
+		t.Fatal("expected error for unknown topic")
+	}
+}
@@ -0,0 +1,135 @@
+// Lesson 04-06: subscribe to contract logs over a WebSocket connection and
+// decode ERC-20 Transfer/Approval events as they arrive.
+//
+//	go run . -url wss://ethereum-rpc.publicnode.com -contract 0xdAC1...
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/lannisite110/hello_world/lesson-04/abifile"
+	"github.com/lannisite110/hello_world/lesson-04/erc20"
+	"github.com/lannisite110/hello_world/lesson-04/erc721"
+	"github.com/lannisite110/hello_world/lesson-04/logging"
+	"github.com/lannisite110/hello_world/lesson-04/shutdown"
+)
+
+// parseLogEvent renders a single contract log. Transfer and Approval events
+// are decoded via the shared erc20 package; anything else is printed raw.
+func parseLogEvent(lg types.Log) string {
+	switch {
+	// ERC-721 first: same Transfer signature as ERC-20, but tokenId is
+	// indexed, so the log carries four topics and empty data.
+	case erc721.IsTransfer(lg):
+		from, to, tokenID, err := erc721.DecodeTransfer(lg)
+		if err != nil {
+			return fmt.Sprintf("block %d tx %s: bad NFT Transfer log: %v", lg.BlockNumber, lg.TxHash, err)
+		}
+		return fmt.Sprintf("block %d tx %s: NFT Transfer %s -> %s tokenId %s",
+			lg.BlockNumber, lg.TxHash, from, to, tokenID)
+	case len(lg.Topics) > 0 && lg.Topics[0] == erc20.TransferTopic():
+		from, to, value, err := erc20.DecodeTransfer(lg)
+		if err != nil {
+			return fmt.Sprintf("block %d tx %s: bad Transfer log: %v", lg.BlockNumber, lg.TxHash, err)
+		}
+		return fmt.Sprintf("block %d tx %s: Transfer %s -> %s value=%s",
+			lg.BlockNumber, lg.TxHash, from, to, value)
+	case len(lg.Topics) == 3 && lg.Topics[0] == erc20.ApprovalTopic():
+		owner := common.BytesToAddress(lg.Topics[1].Bytes())
+		spender := common.BytesToAddress(lg.Topics[2].Bytes())
+		return fmt.Sprintf("block %d tx %s: Approval owner=%s spender=%s",
+			lg.BlockNumber, lg.TxHash, owner, spender)
+	default:
+		return fmt.Sprintf("block %d tx %s: unrecognized log with %d topics",
+			lg.BlockNumber, lg.TxHash, len(lg.Topics))
+	}
+}
+
+func main() {
+	url := flag.String("url", "ws://localhost:8546", "node WebSocket URL")
+	contract := flag.String("contract", "", "contract address to watch")
+	abiPath := flag.String("abi", "", "ABI JSON file; restricts the subscription to its events")
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+
+	logger := logging.Setup()
+
+	if *format != "text" && *format != "json" {
+		logger.Error("bad -format, want text or json", "format", *format)
+		os.Exit(1)
+	}
+
+	if *contract == "" {
+		logger.Error("missing -contract")
+		os.Exit(1)
+	}
+
+	// With -abi, only subscribe to the events that ABI declares instead of
+	// every log of the contract.
+	var topics [][]common.Hash
+	if *abiPath != "" {
+		contractABI, err := abifile.LoadABI(*abiPath)
+		if err != nil {
+			logger.Error("load -abi failed", "path", *abiPath, "err", err)
+			os.Exit(1)
+		}
+		var eventIDs []common.Hash
+		for _, ev := range contractABI.Events {
+			eventIDs = append(eventIDs, ev.ID)
+		}
+		topics = [][]common.Hash{eventIDs}
+	}
+
+	// Stop cleanly on Ctrl-C / SIGTERM.
+	ctx, stop := shutdown.WaitForShutdown(context.Background())
+	defer stop()
+
+	client, err := ethclient.DialContext(ctx, *url)
+	if err != nil {
+		logger.Error("dial failed", "url", *url, "err", err)
+		os.Exit(1)
+	}
+	defer client.Close()
+
+	query := ethereum.FilterQuery{
+		Addresses: []common.Address{common.HexToAddress(*contract)},
+		Topics:    topics,
+	}
+	logs := make(chan types.Log, 64)
+	sub, err := client.SubscribeFilterLogs(ctx, query, logs)
+	if err != nil {
+		logger.Error("subscribe logs failed", "err", err)
+		os.Exit(1)
+	}
+	defer sub.Unsubscribe()
+
+	logger.Info("watching", "contract", *contract, "url", *url)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err := <-sub.Err():
+			logger.Error("subscription failed", "err", err)
+			os.Exit(1)
+		case lg := <-logs:
+			if *format == "json" {
+				line, err := formatEventJSON(lg, erc20.ParsedABI())
+				if err != nil {
+					logger.Warn("decode log failed", "tx", lg.TxHash, "index", lg.Index, "err", err)
+					continue
+				}
+				fmt.Println(line)
+				continue
+			}
+			fmt.Println(parseLogEvent(lg))
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/lannisite110/hello_world/lesson-04/erc721"
+)
+
+func TestParseLogEventERC721Transfer(t *testing.T) {
+	from := common.HexToAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	to := common.HexToAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	lg := types.Log{
+		BlockNumber: 123,
+		Topics: []common.Hash{
+			erc721.TransferTopic(),
+			common.BytesToHash(from.Bytes()),
+			common.BytesToHash(to.Bytes()),
+			common.BigToHash(big.NewInt(42)),
+		},
+	}
+
+	got := parseLogEvent(lg)
+	for _, want := range []string{"NFT Transfer", from.Hex(), to.Hex(), "tokenId 42"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("parseLogEvent = %q, missing %q", got, want)
+		}
+	}
+}
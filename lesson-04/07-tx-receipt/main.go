@@ -0,0 +1,87 @@
+// Lesson 04-07: fetch a transaction receipt and summarize its outcome.
+//
+//	go run . -url https://ethereum-rpc.publicnode.com -tx 0x...
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// receiptReader is the part of ethclient the summary needs, kept narrow so
+// tests can substitute a fake.
+type receiptReader interface {
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// GetReceipt fetches the receipt for txHash.
+func GetReceipt(ctx context.Context, client receiptReader, txHash common.Hash) (*types.Receipt, error) {
+	r, err := client.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("receipt for %s: %w", txHash, err)
+	}
+	return r, nil
+}
+
+// ReceiptStatus renders the receipt's status field.
+func ReceiptStatus(r *types.Receipt) string {
+	if r.Status == types.ReceiptStatusSuccessful {
+		return "success"
+	}
+	return "failed"
+}
+
+// ReceiptGasCost totals what the transaction paid for gas:
+// gas used times the effective gas price, in wei.
+func ReceiptGasCost(r *types.Receipt) *big.Int {
+	price := r.EffectiveGasPrice
+	if price == nil {
+		price = big.NewInt(0)
+	}
+	return new(big.Int).Mul(new(big.Int).SetUint64(r.GasUsed), price)
+}
+
+func printReceiptSummary(r *types.Receipt) {
+	fmt.Printf("Transaction: %s\n", r.TxHash)
+	fmt.Printf("  Status:   %s\n", ReceiptStatus(r))
+	fmt.Printf("  Block:    %s\n", r.BlockNumber)
+	fmt.Printf("  Gas used: %d\n", r.GasUsed)
+	fmt.Printf("  Gas cost: %s wei\n", ReceiptGasCost(r))
+	fmt.Printf("  Logs:     %d\n", len(r.Logs))
+	if r.ContractAddress != (common.Address{}) {
+		fmt.Printf("  Deployed: %s\n", r.ContractAddress)
+	}
+}
+
+func main() {
+	url := flag.String("url", "http://localhost:8545", "node RPC URL")
+	tx := flag.String("tx", "", "transaction hash")
+	flag.Parse()
+
+	if *tx == "" {
+		log.Fatal("missing -tx")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	client, err := ethclient.DialContext(ctx, *url)
+	if err != nil {
+		log.Fatalf("dial %s: %v", *url, err)
+	}
+	defer client.Close()
+
+	receipt, err := GetReceipt(ctx, client, common.HexToHash(*tx))
+	if err != nil {
+		log.Fatal(err)
+	}
+	printReceiptSummary(receipt)
+}
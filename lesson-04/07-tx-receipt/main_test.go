@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// fakeReceiptClient serves one canned receipt.
+type fakeReceiptClient struct {
+	receipt *types.Receipt
+	err     error
+}
+
+func (f *fakeReceiptClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return f.receipt, f.err
+}
+
+func TestGetReceiptSummary(t *testing.T) {
+	client := &fakeReceiptClient{receipt: &types.Receipt{
+		Status:            types.ReceiptStatusSuccessful,
+		GasUsed:           21000,
+		EffectiveGasPrice: big.NewInt(30_000_000_000), // 30 gwei
+		BlockNumber:       big.NewInt(100),
+	}}
+
+	receipt, err := GetReceipt(context.Background(), client, common.HexToHash("0x1"))
+	if err != nil {
+		t.Fatalf("GetReceipt: %v", err)
+	}
+	if got := ReceiptStatus(receipt); got != "success" {
+		t.Errorf("status = %q, want success", got)
+	}
+
+	want := new(big.Int).Mul(big.NewInt(21000), big.NewInt(30_000_000_000))
+	if got := ReceiptGasCost(receipt); got.Cmp(want) != 0 {
+		t.Errorf("gas cost = %s, want %s", got, want)
+	}
+}
+
+func TestReceiptStatusFailed(t *testing.T) {
+	if got := ReceiptStatus(&types.Receipt{Status: types.ReceiptStatusFailed}); got != "failed" {
+		t.Fatalf("status = %q, want failed", got)
+	}
+}
+
+func TestGetReceiptError(t *testing.T) {
+	boom := errors.New("not found")
+	_, err := GetReceipt(context.Background(), &fakeReceiptClient{err: boom}, common.HexToHash("0x2"))
+	if !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want wrapped boom", err)
+	}
+}
+
+func TestReceiptGasCostNilPrice(t *testing.T) {
+	// Pre-EIP-1559 receipts from some clients omit the effective price.
+	r := &types.Receipt{GasUsed: 21000}
+	if got := ReceiptGasCost(r); got.Sign() != 0 {
+		t.Fatalf("gas cost = %s, want 0", got)
+	}
+}
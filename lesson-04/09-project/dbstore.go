@@ -0,0 +1,110 @@
+package main
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Store is the contract shared by the in-memory and database-backed event
+// stores, so the poller and handlers work against either.
+type Store interface {
+	Add(ev TransferEvent) bool
+	List() []TransferEvent
+}
+
+// storedEvent is the database row for one TransferEvent. The unique index
+// over tx_hash and log_index gives the same dedup guarantee the in-memory
+// store enforces with its seen-set.
+type storedEvent struct {
+	ID       uint   `gorm:"primarykey"`
+	Block    uint64 `gorm:"index"`
+	TxHash   string `gorm:"size:66;uniqueIndex:idx_tx_log"`
+	LogIndex uint   `gorm:"uniqueIndex:idx_tx_log"`
+	From     string `gorm:"column:from_addr;size:42;index"`
+	To       string `gorm:"column:to_addr;size:42;index"`
+	Value    string
+}
+
+func (storedEvent) TableName() string { return "transfer_events" }
+
+func (e storedEvent) toTransferEvent() TransferEvent {
+	return TransferEvent{
+		Block:    e.Block,
+		TxHash:   e.TxHash,
+		LogIndex: e.LogIndex,
+		From:     e.From,
+		To:       e.To,
+		Value:    e.Value,
+	}
+}
+
+// GormEventStore persists transfer events, so history survives restarts and
+// can be queried by address or block range in SQL.
+type GormEventStore struct {
+	db *gorm.DB
+}
+
+// NewGormEventStore migrates the event table and returns the store.
+func NewGormEventStore(db *gorm.DB) (*GormEventStore, error) {
+	if err := db.AutoMigrate(&storedEvent{}); err != nil {
+		return nil, err
+	}
+	return &GormEventStore{db: db}, nil
+}
+
+// Add persists ev, reporting whether it was new; the unique index swallows
+// duplicates via ON CONFLICT DO NOTHING.
+func (s *GormEventStore) Add(ev TransferEvent) bool {
+	res := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&storedEvent{
+		Block:    ev.Block,
+		TxHash:   ev.TxHash,
+		LogIndex: ev.LogIndex,
+		From:     ev.From,
+		To:       ev.To,
+		Value:    ev.Value,
+	})
+	return res.Error == nil && res.RowsAffected == 1
+}
+
+// List returns every stored event in chain order.
+func (s *GormEventStore) List() []TransferEvent {
+	var rows []storedEvent
+	if err := s.db.Order("block, log_index").Find(&rows).Error; err != nil {
+		return nil
+	}
+	events := make([]TransferEvent, len(rows))
+	for i, row := range rows {
+		events[i] = row.toTransferEvent()
+	}
+	return events
+}
+
+// ByAddress returns events where addr is sender or recipient, in chain order.
+func (s *GormEventStore) ByAddress(addr string) ([]TransferEvent, error) {
+	var rows []storedEvent
+	err := s.db.Where("from_addr = ? OR to_addr = ?", addr, addr).
+		Order("block, log_index").Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	events := make([]TransferEvent, len(rows))
+	for i, row := range rows {
+		events[i] = row.toTransferEvent()
+	}
+	return events, nil
+}
+
+// ByBlockRange returns events with from <= block <= to, in chain order.
+func (s *GormEventStore) ByBlockRange(from, to uint64) ([]TransferEvent, error) {
+	var rows []storedEvent
+	err := s.db.Where("block BETWEEN ? AND ?", from, to).
+		Order("block, log_index").Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	events := make([]TransferEvent, len(rows))
+	for i, row := range rows {
+		events[i] = row.toTransferEvent()
+	}
+	return events, nil
+}
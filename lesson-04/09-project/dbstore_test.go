@@ -0,0 +1,89 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/lannisite110/hello_world/lesson-02/testutil"
+)
+
+func newGormStore(t *testing.T) *GormEventStore {
+	t.Helper()
+	store, err := NewGormEventStore(testutil.NewTestDB(t))
+	if err != nil {
+		t.Fatalf("NewGormEventStore: %v", err)
+	}
+	return store
+}
+
+// Both implementations must satisfy the shared interface.
+var (
+	_ Store = (*EventStore)(nil)
+	_ Store = (*GormEventStore)(nil)
+)
+
+func TestGormStoreAddAndDedup(t *testing.T) {
+	store := newGormStore(t)
+
+	if !store.Add(testEvent(1)) {
+		t.Fatal("first add should report true")
+	}
+	if store.Add(testEvent(1)) {
+		t.Fatal("duplicate tx_hash+log_index should report false")
+	}
+
+	// Same tx hash but a different log index is a distinct event.
+	other := testEvent(1)
+	other.LogIndex = 1
+	if !store.Add(other) {
+		t.Fatal("different log index should be accepted")
+	}
+
+	if got := store.List(); len(got) != 2 {
+		t.Fatalf("stored %d events, want 2", len(got))
+	}
+}
+
+func TestGormStoreQueryByAddress(t *testing.T) {
+	store := newGormStore(t)
+
+	a := testEvent(1)
+	a.From, a.To = "0xaaa", "0xbbb"
+	b := testEvent(2)
+	b.From, b.To = "0xccc", "0xaaa"
+	c := testEvent(3)
+	c.From, c.To = "0xccc", "0xddd"
+	for _, ev := range []TransferEvent{a, b, c} {
+		store.Add(ev)
+	}
+
+	got, err := store.ByAddress("0xaaa")
+	if err != nil {
+		t.Fatalf("ByAddress: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("found %d events for 0xaaa, want 2 (sender or recipient)", len(got))
+	}
+	if got[0].Block != 1 || got[1].Block != 2 {
+		t.Fatalf("events out of chain order: %+v", got)
+	}
+}
+
+func TestGormStoreQueryByBlockRange(t *testing.T) {
+	store := newGormStore(t)
+	for i := 1; i <= 10; i++ {
+		store.Add(testEvent(i))
+	}
+
+	got, err := store.ByBlockRange(3, 6)
+	if err != nil {
+		t.Fatalf("ByBlockRange: %v", err)
+	}
+	if len(got) != 4 {
+		t.Fatalf("found %d events in [3,6], want 4", len(got))
+	}
+	for _, ev := range got {
+		if ev.Block < 3 || ev.Block > 6 {
+			t.Fatalf("event block %d outside range", ev.Block)
+		}
+	}
+}
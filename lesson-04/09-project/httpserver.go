@@ -0,0 +1,66 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+)
+
+// serverConfig collects the HTTP server knobs. Each field comes from an
+// environment variable with a sane default:
+//
+//	HTTP_ADDR           listen address       (default: the -addr flag)
+//	HTTP_READ_TIMEOUT   read timeout         (default 5s)
+//	HTTP_WRITE_TIMEOUT  write timeout        (default 10s)
+//	HTTP_IDLE_TIMEOUT   idle keepalive limit (default 60s)
+//
+// Timeouts use Go duration syntax ("5s", "1m30s").
+type serverConfig struct {
+	addr         string
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+	idleTimeout  time.Duration
+}
+
+// envDuration reads a duration from the environment, keeping def when the
+// variable is unset and warning (but not failing) on unparsable values.
+func envDuration(key string, def time.Duration) time.Duration {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return def
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		slog.Warn("invalid duration in environment, using default", "var", key, "value", raw, "default", def)
+		return def
+	}
+	return d
+}
+
+// loadServerConfig resolves the config from the environment, falling back to
+// defaultAddr (the -addr flag) when HTTP_ADDR is unset.
+func loadServerConfig(defaultAddr string) serverConfig {
+	addr := os.Getenv("HTTP_ADDR")
+	if addr == "" {
+		addr = defaultAddr
+	}
+	return serverConfig{
+		addr:         addr,
+		readTimeout:  envDuration("HTTP_READ_TIMEOUT", 5*time.Second),
+		writeTimeout: envDuration("HTTP_WRITE_TIMEOUT", 10*time.Second),
+		idleTimeout:  envDuration("HTTP_IDLE_TIMEOUT", 60*time.Second),
+	}
+}
+
+// newHTTPServer builds the server from cfg. Listening is left to the caller,
+// so a bad address only surfaces at ListenAndServe.
+func newHTTPServer(cfg serverConfig, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:         cfg.addr,
+		Handler:      handler,
+		ReadTimeout:  cfg.readTimeout,
+		WriteTimeout: cfg.writeTimeout,
+		IdleTimeout:  cfg.idleTimeout,
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadServerConfigFromEnvironment(t *testing.T) {
+	t.Setenv("HTTP_ADDR", ":9999")
+	t.Setenv("HTTP_READ_TIMEOUT", "2s")
+	t.Setenv("HTTP_WRITE_TIMEOUT", "3s")
+	t.Setenv("HTTP_IDLE_TIMEOUT", "90s")
+
+	cfg := loadServerConfig(":8080")
+	srv := newHTTPServer(cfg, http.NewServeMux())
+
+	if srv.Addr != ":9999" {
+		t.Fatalf("Addr = %q, want env override :9999", srv.Addr)
+	}
+	if srv.ReadTimeout != 2*time.Second || srv.WriteTimeout != 3*time.Second || srv.IdleTimeout != 90*time.Second {
+		t.Fatalf("timeouts = %v/%v/%v, want 2s/3s/90s",
+			srv.ReadTimeout, srv.WriteTimeout, srv.IdleTimeout)
+	}
+}
+
+func TestLoadServerConfigDefaults(t *testing.T) {
+	t.Setenv("HTTP_ADDR", "")
+	t.Setenv("HTTP_READ_TIMEOUT", "")
+	t.Setenv("HTTP_WRITE_TIMEOUT", "not-a-duration")
+	t.Setenv("HTTP_IDLE_TIMEOUT", "")
+
+	cfg := loadServerConfig(":8080")
+	if cfg.addr != ":8080" {
+		t.Fatalf("addr = %q, want the flag fallback :8080", cfg.addr)
+	}
+	if cfg.readTimeout != 5*time.Second {
+		t.Fatalf("readTimeout = %v, want default 5s", cfg.readTimeout)
+	}
+	// Garbage falls back to the default rather than failing startup.
+	if cfg.writeTimeout != 10*time.Second {
+		t.Fatalf("writeTimeout = %v, want default 10s", cfg.writeTimeout)
+	}
+	if cfg.idleTimeout != 60*time.Second {
+		t.Fatalf("idleTimeout = %v, want default 60s", cfg.idleTimeout)
+	}
+}
+
+func TestNewHTTPServerBadAddressFailsAtListen(t *testing.T) {
+	srv := newHTTPServer(serverConfig{addr: "not a listen address"}, http.NewServeMux())
+
+	err := srv.ListenAndServe()
+	if err == nil {
+		t.Fatal("expected listen error for a bogus address")
+	}
+	if !strings.Contains(err.Error(), "address") {
+		t.Fatalf("err = %v, want it to name the address problem", err)
+	}
+}
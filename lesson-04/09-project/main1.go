@@ -0,0 +1,392 @@
+// Lesson 04-09: a small ERC-20 transfer indexer. It polls the chain for
+// Transfer logs of one contract, keeps the most recent events in memory and
+// serves them over HTTP at /event.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/lannisite110/hello_world/lesson-04/abifile"
+	"github.com/lannisite110/hello_world/lesson-04/erc20"
+	"github.com/lannisite110/hello_world/lesson-04/logging"
+	"github.com/lannisite110/hello_world/lesson-04/shutdown"
+	"github.com/lannisite110/hello_world/pkg/eventbus"
+)
+
+// TransferEvent is one decoded ERC-20 Transfer.
+type TransferEvent struct {
+	Block    uint64 `json:"block"`
+	TxHash   string `json:"tx_hash"`
+	LogIndex uint   `json:"log_index"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Value    string `json:"value"`
+}
+
+// eventKey identifies an event uniquely on chain.
+type eventKey struct {
+	txHash   string
+	logIndex uint
+}
+
+// EventStore keeps the most recent events in a bounded ring buffer and
+// remembers which (tx hash, log index) pairs it holds, so re-scanning a
+// block range never stores the same event twice.
+type EventStore struct {
+	mu     sync.Mutex
+	events []TransferEvent
+	seen   map[eventKey]struct{}
+	max    int
+
+	totalAdded uint64
+	lastBlock  uint64
+	lastScan   time.Time
+}
+
+// NewEventStore returns a store that retains at most max events.
+func NewEventStore(max int) *EventStore {
+	return &EventStore{max: max, seen: make(map[eventKey]struct{})}
+}
+
+// Add appends an event, evicting the oldest when the buffer is full, and
+// reports whether the event was newly added. Events already in the buffer
+// are silently ignored.
+func (s *EventStore) Add(ev TransferEvent) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := eventKey{txHash: ev.TxHash, logIndex: ev.LogIndex}
+	if _, dup := s.seen[key]; dup {
+		return false
+	}
+	if len(s.events) >= s.max {
+		evicted := s.events[0]
+		delete(s.seen, eventKey{txHash: evicted.TxHash, logIndex: evicted.LogIndex})
+		s.events = s.events[1:]
+	}
+	s.events = append(s.events, ev)
+	s.seen[key] = struct{}{}
+	s.totalAdded++
+	return true
+}
+
+// RecordScan notes that the poller finished scanning up to block.
+func (s *EventStore) RecordScan(block uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastBlock = block
+	s.lastScan = time.Now()
+}
+
+// StoreStats is the JSON shape served at /stats.
+type StoreStats struct {
+	TotalEvents    uint64    `json:"total_events"`
+	BufferedEvents int       `json:"buffered_events"`
+	LastBlock      uint64    `json:"last_block"`
+	LastScanTime   time.Time `json:"last_scan_time"`
+}
+
+// Stats returns a consistent snapshot of the store's counters.
+func (s *EventStore) Stats() StoreStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return StoreStats{
+		TotalEvents:    s.totalAdded,
+		BufferedEvents: len(s.events),
+		LastBlock:      s.lastBlock,
+		LastScanTime:   s.lastScan,
+	}
+}
+
+// List returns a copy of the stored events, oldest first.
+func (s *EventStore) List() []TransferEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]TransferEvent, len(s.events))
+	copy(out, s.events)
+	return out
+}
+
+// chainReader is the part of ethclient the poller needs, kept narrow so tests
+// can substitute a fake.
+type chainReader interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// scanRecorder receives the poller's scan progress; *EventStore implements it.
+type scanRecorder interface {
+	RecordScan(block uint64)
+}
+
+// pollTransactionEvents scans new blocks for Transfer logs of the contract on
+// a fixed interval until the context is cancelled, publishing each decoded
+// transfer on the bus so any number of consumers (store, stats, pushers) can
+// subscribe. Scan progress goes to the recorder for /stats.
+func pollTransactionEvents(ctx context.Context, client chainReader, bus *eventbus.Bus[TransferEvent], scans scanRecorder, contract common.Address, transferTopic common.Hash, interval time.Duration) {
+	var lastBlock uint64
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		head, err := client.BlockNumber(ctx)
+		if err != nil {
+			slog.Warn("poll: block number failed", "err", err)
+			continue
+		}
+		if head <= lastBlock {
+			continue
+		}
+		from := lastBlock + 1
+		if lastBlock == 0 {
+			from = head // first pass: start at the tip
+		}
+
+		logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(head),
+			Addresses: []common.Address{contract},
+			Topics:    [][]common.Hash{{transferTopic}},
+		})
+		if err != nil {
+			slog.Warn("poll: filter logs failed", "from", from, "to", head, "err", err)
+			continue
+		}
+		for _, lg := range logs {
+			fromAddr, toAddr, value, err := erc20.DecodeTransfer(lg)
+			if err != nil {
+				slog.Warn("poll: decode log failed", "tx", lg.TxHash, "index", lg.Index, "err", err)
+				continue
+			}
+			bus.Publish(TransferEvent{
+				Block:    lg.BlockNumber,
+				TxHash:   lg.TxHash.Hex(),
+				LogIndex: lg.Index,
+				From:     fromAddr.Hex(),
+				To:       toAddr.Hex(),
+				Value:    value.String(),
+			})
+		}
+		lastBlock = head
+		scans.RecordScan(head)
+	}
+}
+
+// malformedRequest is a decode failure carrying the HTTP status it should be
+// reported with.
+type malformedRequest struct {
+	status int
+	msg    string
+}
+
+func (e *malformedRequest) Error() string { return e.msg }
+
+// decodeJSONBody reads one JSON value from the request into dst, enforcing a
+// byte limit and rejecting unknown fields and trailing data. Failures come
+// back as *malformedRequest with an appropriate status code.
+func decodeJSONBody(w http.ResponseWriter, r *http.Request, dst any, maxBytes int64) error {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(dst); err != nil {
+		var (
+			syntaxErr *json.SyntaxError
+			typeErr   *json.UnmarshalTypeError
+			maxErr    *http.MaxBytesError
+		)
+		switch {
+		case errors.As(err, &maxErr):
+			return &malformedRequest{
+				status: http.StatusRequestEntityTooLarge,
+				msg:    fmt.Sprintf("request body exceeds %d bytes", maxBytes),
+			}
+		case errors.As(err, &syntaxErr):
+			return &malformedRequest{
+				status: http.StatusBadRequest,
+				msg:    fmt.Sprintf("malformed JSON at offset %d", syntaxErr.Offset),
+			}
+		case errors.As(err, &typeErr):
+			return &malformedRequest{
+				status: http.StatusBadRequest,
+				msg:    fmt.Sprintf("wrong JSON type for field %q", typeErr.Field),
+			}
+		case strings.HasPrefix(err.Error(), "json: unknown field "):
+			return &malformedRequest{
+				status: http.StatusBadRequest,
+				msg:    err.Error(),
+			}
+		case errors.Is(err, io.EOF):
+			return &malformedRequest{status: http.StatusBadRequest, msg: "empty request body"}
+		default:
+			return &malformedRequest{status: http.StatusBadRequest, msg: err.Error()}
+		}
+	}
+	if dec.More() {
+		return &malformedRequest{status: http.StatusBadRequest, msg: "request body must contain a single JSON value"}
+	}
+	return nil
+}
+
+// writeRequestError renders a decode failure with its status code.
+func writeRequestError(w http.ResponseWriter, err error) {
+	var mr *malformedRequest
+	if errors.As(err, &mr) {
+		http.Error(w, mr.msg, mr.status)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+// maxEventBody bounds manually posted events; real ones are tiny.
+const maxEventBody = 64 << 10
+
+func eventHandler(store Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			// Manual injection, mostly useful for demos and tests.
+			var ev TransferEvent
+			if err := decodeJSONBody(w, r, &ev, maxEventBody); err != nil {
+				writeRequestError(w, err)
+				return
+			}
+			added := store.Add(ev)
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]bool{"added": added})
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(store.List()); err != nil {
+				slog.Error("encode events failed", "err", err)
+			}
+		}
+	}
+}
+
+func statsHandler(store *EventStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(store.Stats()); err != nil {
+			slog.Error("encode stats failed", "err", err)
+		}
+	}
+}
+
+func main() {
+	url := flag.String("url", "http://localhost:8545", "node RPC URL")
+	contract := flag.String("contract", "", "ERC-20 contract address to index")
+	addr := flag.String("addr", ":8080", "HTTP listen address")
+	interval := flag.Duration("interval", 12*time.Second, "poll interval")
+	abiPath := flag.String("abi", "", "ABI JSON file overriding the embedded ERC-20 ABI")
+	flag.Parse()
+
+	logger := logging.Setup()
+
+	if *contract == "" {
+		logger.Error("missing -contract")
+		os.Exit(1)
+	}
+
+	transferTopic := erc20.TransferTopic()
+	if *abiPath != "" {
+		contractABI, err := abifile.LoadABI(*abiPath)
+		if err != nil {
+			logger.Error("load -abi failed", "path", *abiPath, "err", err)
+			os.Exit(1)
+		}
+		ev, ok := contractABI.Events["Transfer"]
+		if !ok {
+			logger.Error("ABI declares no Transfer event", "path", *abiPath)
+			os.Exit(1)
+		}
+		transferTopic = ev.ID
+	}
+
+	ctx, stop := shutdown.WaitForShutdown(context.Background())
+	defer stop()
+
+	client, err := ethclient.DialContext(ctx, *url)
+	if err != nil {
+		logger.Error("dial failed", "url", *url, "err", err)
+		os.Exit(1)
+	}
+	defer client.Close()
+
+	store := NewEventStore(1000)
+	bus := eventbus.New[TransferEvent](256)
+	storeSub := bus.Subscribe()
+
+	// Track the background goroutines so shutdown can wait for them instead
+	// of returning while the poller is mid-scan.
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				bus.Unsubscribe(storeSub)
+				return
+			case ev := <-storeSub:
+				store.Add(ev)
+			}
+		}
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		pollTransactionEvents(ctx, client, bus, store, common.HexToAddress(*contract), transferTopic, *interval)
+	}()
+
+	mux := http.NewServeMux()
+	mux.Handle("/event", eventHandler(store))
+	mux.Handle("/stats", statsHandler(store))
+	mux.Handle("/ws", wsHandler(bus))
+	cfg := loadServerConfig(*addr)
+	server := newHTTPServer(cfg, mux)
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer shutdownCancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Error("server shutdown failed", "err", err)
+		}
+	}()
+
+	logger.Info("indexing", "contract", *contract, "addr", cfg.addr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		logger.Error("listen failed", "addr", cfg.addr, "err", err)
+		os.Exit(1)
+	}
+	logger.Info("http server stopped")
+
+	// ListenAndServe only returns cleanly once ctx is cancelled, so the
+	// poller and the store bridge are already winding down; wait them out.
+	wg.Wait()
+	logger.Info("poller stopped, shutdown complete")
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/lannisite110/hello_world/lesson-04/erc20"
+	"github.com/lannisite110/hello_world/pkg/eventbus"
+)
+
+// fakeChain serves a fixed head and log set to the poller.
+type fakeChain struct {
+	head uint64
+	logs []types.Log
+}
+
+func (f *fakeChain) BlockNumber(context.Context) (uint64, error) { return f.head, nil }
+
+func (f *fakeChain) FilterLogs(context.Context, ethereum.FilterQuery) ([]types.Log, error) {
+	return f.logs, nil
+}
+
+// fakeStore records Add calls; it satisfies the Store interface.
+type fakeStore struct {
+	mu     sync.Mutex
+	events []TransferEvent
+	scans  []uint64
+}
+
+func (f *fakeStore) Add(ev TransferEvent) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, ev)
+	return true
+}
+
+func (f *fakeStore) List() []TransferEvent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]TransferEvent(nil), f.events...)
+}
+
+func (f *fakeStore) RecordScan(block uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.scans = append(f.scans, block)
+}
+
+// transferLog builds a well-formed ERC-20 Transfer log.
+func transferLog(block uint64, from, to common.Address, value int64) types.Log {
+	return types.Log{
+		BlockNumber: block,
+		TxHash:      common.HexToHash("0xabc"),
+		Index:       3,
+		Topics: []common.Hash{
+			erc20.TransferTopic(),
+			common.BytesToHash(from.Bytes()),
+			common.BytesToHash(to.Bytes()),
+		},
+		Data: common.BigToHash(big.NewInt(value)).Bytes(),
+	}
+}
+
+func TestPollerDeliversDecodedEventsToStore(t *testing.T) {
+	from := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	to := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	chain := &fakeChain{head: 42, logs: []types.Log{transferLog(42, from, to, 1000)}}
+
+	var store fakeStore
+	bus := eventbus.New[TransferEvent](16)
+	sub := bus.Subscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// Bridge the bus into the store the same way main does.
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev := <-sub:
+				store.Add(ev)
+			}
+		}
+	}()
+	go pollTransactionEvents(ctx, chain, bus, &store, common.Address{}, erc20.TransferTopic(), time.Millisecond)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(store.List()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("poller never delivered an event")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+
+	got := store.List()[0]
+	want := TransferEvent{
+		Block:    42,
+		TxHash:   common.HexToHash("0xabc").Hex(),
+		LogIndex: 3,
+		From:     from.Hex(),
+		To:       to.Hex(),
+		Value:    "1000",
+	}
+	if got != want {
+		t.Fatalf("decoded event = %+v, want %+v", got, want)
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if len(store.scans) == 0 || store.scans[0] != 42 {
+		t.Fatalf("scan progress = %v, want head 42 recorded", store.scans)
+	}
+}
+
+func TestPollerGoroutineExitsOnShutdown(t *testing.T) {
+	chain := &fakeChain{head: 1}
+	var store fakeStore
+	bus := eventbus.New[TransferEvent](16)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Run the poller under a WaitGroup exactly like main does, so the test
+	// proves shutdown can join it rather than leak the goroutine.
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		pollTransactionEvents(ctx, chain, bus, &store, common.Address{}, erc20.TransferTopic(), time.Millisecond)
+	}()
+
+	// Let it complete at least one tick before pulling the plug.
+	time.Sleep(5 * time.Millisecond)
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("poller goroutine did not exit after cancellation")
+	}
+}
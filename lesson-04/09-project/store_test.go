@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func testEvent(i int) TransferEvent {
+	return TransferEvent{
+		Block:    uint64(i),
+		TxHash:   fmt.Sprintf("0x%064x", i),
+		LogIndex: 0,
+		From:     "0xfrom",
+		To:       "0xto",
+		Value:    "1",
+	}
+}
+
+func TestEventStoreDedup(t *testing.T) {
+	store := NewEventStore(10)
+
+	if !store.Add(testEvent(1)) {
+		t.Fatal("first Add returned false")
+	}
+	if store.Add(testEvent(1)) {
+		t.Fatal("duplicate Add returned true")
+	}
+	if got := len(store.List()); got != 1 {
+		t.Fatalf("stored %d events, want 1", got)
+	}
+
+	// Same tx hash, different log index is a distinct event.
+	other := testEvent(1)
+	other.LogIndex = 1
+	if !store.Add(other) {
+		t.Fatal("distinct log index rejected as duplicate")
+	}
+}
+
+func TestEventStoreEvictionCleansSeenSet(t *testing.T) {
+	store := NewEventStore(2)
+
+	store.Add(testEvent(1))
+	store.Add(testEvent(2))
+	store.Add(testEvent(3)) // evicts event 1
+
+	events := store.List()
+	if len(events) != 2 || events[0].Block != 2 || events[1].Block != 3 {
+		t.Fatalf("List = %+v, want events 2 and 3", events)
+	}
+
+	// The evicted event's key must be gone, so it can be re-added.
+	if !store.Add(testEvent(1)) {
+		t.Fatal("evicted event still tracked as seen")
+	}
+}
+
+func TestStatsEndpoint(t *testing.T) {
+	store := NewEventStore(2)
+	store.Add(testEvent(1))
+	store.Add(testEvent(2))
+	store.Add(testEvent(3)) // evicted below capacity, still counts toward total
+	store.Add(testEvent(3)) // duplicate, must not count
+	store.RecordScan(99)
+
+	w := httptest.NewRecorder()
+	statsHandler(store)(w, httptest.NewRequest(http.MethodGet, "/stats", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	var stats StoreStats
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("parse stats: %v", err)
+	}
+	if stats.TotalEvents != 3 {
+		t.Errorf("total_events = %d, want 3", stats.TotalEvents)
+	}
+	if stats.BufferedEvents != 2 {
+		t.Errorf("buffered_events = %d, want 2", stats.BufferedEvents)
+	}
+	if stats.LastBlock != 99 {
+		t.Errorf("last_block = %d, want 99", stats.LastBlock)
+	}
+	if stats.LastScanTime.IsZero() {
+		t.Error("last_scan_time is zero")
+	}
+}
+
+func postEvent(t *testing.T, store *EventStore, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/event", strings.NewReader(body))
+	eventHandler(store)(w, req)
+	return w
+}
+
+func TestPostEventValidBody(t *testing.T) {
+	store := NewEventStore(10)
+	w := postEvent(t, store, `{"block":5,"tx_hash":"0xabc","log_index":0,"from":"0x1","to":"0x2","value":"10"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (%s)", w.Code, w.Body.String())
+	}
+	if got := len(store.List()); got != 1 {
+		t.Fatalf("stored %d events, want 1", got)
+	}
+}
+
+func TestPostEventUnknownField(t *testing.T) {
+	store := NewEventStore(10)
+	w := postEvent(t, store, `{"block":5,"bogus":true}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "unknown field") {
+		t.Fatalf("body = %q, want unknown-field message", w.Body.String())
+	}
+}
+
+func TestPostEventOversizedBody(t *testing.T) {
+	store := NewEventStore(10)
+	huge := `{"from":"` + strings.Repeat("x", maxEventBody) + `"}`
+	w := postEvent(t, store, huge)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want 413", w.Code)
+	}
+}
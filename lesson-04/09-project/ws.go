@@ -0,0 +1,60 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/lannisite110/hello_world/pkg/eventbus"
+)
+
+// wsWriteTimeout bounds each push; a client that can't keep up is dropped.
+const wsWriteTimeout = 5 * time.Second
+
+var wsUpgrader = websocket.Upgrader{
+	// The demo serves no browser origin of its own, so accept all origins.
+	CheckOrigin: func(*http.Request) bool { return true },
+}
+
+// wsHandler upgrades the connection and pushes every TransferEvent from the
+// bus to the client as JSON. Backpressure is handled twice over: the bus
+// drops events for a subscriber whose buffer is full, and a write that
+// misses the deadline disconnects the client.
+func wsHandler(bus *eventbus.Bus[TransferEvent]) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			slog.Warn("ws: upgrade failed", "err", err)
+			return
+		}
+		defer conn.Close()
+
+		sub := bus.Subscribe()
+		defer bus.Unsubscribe(sub)
+
+		// Drain client frames purely to notice the disconnect.
+		gone := make(chan struct{})
+		go func() {
+			defer close(gone)
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+
+		for {
+			select {
+			case <-gone:
+				return
+			case ev := <-sub:
+				conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+				if err := conn.WriteJSON(ev); err != nil {
+					return
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/lannisite110/hello_world/pkg/eventbus"
+)
+
+func TestWebSocketPushesEvents(t *testing.T) {
+	bus := eventbus.New[TransferEvent](16)
+	server := httptest.NewServer(wsHandler(bus))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("dial %s: %v", wsURL, err)
+	}
+	defer conn.Close()
+
+	// Wait for the handler to register its bus subscription.
+	deadline := time.Now().Add(2 * time.Second)
+	for bus.Subscribers() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("handler never subscribed to the bus")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	want := testEvent(7)
+	bus.Publish(want)
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var got TransferEvent
+	if err := conn.ReadJSON(&got); err != nil {
+		t.Fatalf("read pushed event: %v", err)
+	}
+	if got != want {
+		t.Fatalf("pushed event = %+v, want %+v", got, want)
+	}
+}
+
+func TestWebSocketUnsubscribesOnDisconnect(t *testing.T) {
+	bus := eventbus.New[TransferEvent](16)
+	server := httptest.NewServer(wsHandler(bus))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for bus.Subscribers() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("handler never subscribed to the bus")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	conn.Close()
+	deadline = time.Now().Add(2 * time.Second)
+	for bus.Subscribers() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("handler kept its subscription after the client left")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
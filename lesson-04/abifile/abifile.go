@@ -0,0 +1,43 @@
+// Package abifile loads contract ABIs from disk so the lesson-04 tools can
+// work with contracts beyond the embedded ERC-20 subset.
+package abifile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// LoadABI reads an ABI from path. Both a bare ABI array and a
+// Truffle/Hardhat artifact with an "abi" field are accepted.
+func LoadABI(path string) (abi.ABI, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("read ABI file: %w", err)
+	}
+
+	raw := bytes.TrimSpace(data)
+	if len(raw) > 0 && raw[0] == '{' {
+		// Compiler artifact: unwrap the "abi" field.
+		var artifact struct {
+			ABI json.RawMessage `json:"abi"`
+		}
+		if err := json.Unmarshal(raw, &artifact); err != nil {
+			return abi.ABI{}, fmt.Errorf("parse artifact %s: %w", path, err)
+		}
+		if len(artifact.ABI) == 0 {
+			return abi.ABI{}, fmt.Errorf("artifact %s has no \"abi\" field", path)
+		}
+		raw = artifact.ABI
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(string(raw)))
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("parse ABI %s: %w", path, err)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,59 @@
+package abifile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lannisite110/hello_world/lesson-04/erc20"
+)
+
+func writeTemp(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestLoadABIBareArray(t *testing.T) {
+	path := writeTemp(t, "erc20.json", erc20.ABIJSON)
+
+	parsed, err := LoadABI(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	ev, ok := parsed.Events["Transfer"]
+	if !ok {
+		t.Fatal("Transfer event missing")
+	}
+	if ev.ID != erc20.TransferTopic() {
+		t.Fatalf("Transfer topic = %s, want %s", ev.ID, erc20.TransferTopic())
+	}
+}
+
+func TestLoadABIArtifact(t *testing.T) {
+	path := writeTemp(t, "artifact.json",
+		`{"contractName":"Token","abi":`+erc20.ABIJSON+`,"bytecode":"0x"}`)
+
+	parsed, err := LoadABI(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if _, ok := parsed.Events["Approval"]; !ok {
+		t.Fatal("Approval event missing from artifact ABI")
+	}
+}
+
+func TestLoadABIErrors(t *testing.T) {
+	if _, err := LoadABI(filepath.Join(t.TempDir(), "absent.json")); err == nil {
+		t.Error("missing file accepted")
+	}
+	if _, err := LoadABI(writeTemp(t, "bad.json", `{"not json`)); err == nil {
+		t.Error("malformed JSON accepted")
+	}
+	if _, err := LoadABI(writeTemp(t, "noabi.json", `{"contractName":"X"}`)); err == nil {
+		t.Error("artifact without abi field accepted")
+	}
+}
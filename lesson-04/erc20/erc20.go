@@ -0,0 +1,80 @@
+// Package erc20 holds the ERC-20 event ABI shared by the lesson-04 examples
+// so each tool doesn't re-declare and re-parse the same JSON.
+package erc20
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"strings"
+)
+
+// ABIJSON is the event subset of the ERC-20 ABI used by the examples.
+const ABIJSON = `[
+	{"anonymous":false,"inputs":[
+		{"indexed":true,"name":"from","type":"address"},
+		{"indexed":true,"name":"to","type":"address"},
+		{"indexed":false,"name":"value","type":"uint256"}],
+	 "name":"Transfer","type":"event"},
+	{"anonymous":false,"inputs":[
+		{"indexed":true,"name":"owner","type":"address"},
+		{"indexed":true,"name":"spender","type":"address"},
+		{"indexed":false,"name":"value","type":"uint256"}],
+	 "name":"Approval","type":"event"}
+]`
+
+var (
+	parseOnce sync.Once
+	parsed    abi.ABI
+
+	transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	approvalTopic = crypto.Keccak256Hash([]byte("Approval(address,address,uint256)"))
+)
+
+// ErrNotTransfer is returned by DecodeTransfer for logs that are not ERC-20
+// Transfer events.
+var ErrNotTransfer = errors.New("log is not an ERC-20 Transfer event")
+
+// ParsedABI returns the shared event ABI, parsed exactly once.
+func ParsedABI() abi.ABI {
+	parseOnce.Do(func() {
+		var err error
+		parsed, err = abi.JSON(strings.NewReader(ABIJSON))
+		if err != nil {
+			panic(fmt.Sprintf("erc20: parse embedded ABI: %v", err))
+		}
+	})
+	return parsed
+}
+
+// TransferTopic returns the topic hash of Transfer(address,address,uint256).
+func TransferTopic() common.Hash { return transferTopic }
+
+// ApprovalTopic returns the topic hash of Approval(address,address,uint256).
+func ApprovalTopic() common.Hash { return approvalTopic }
+
+// DecodeTransfer decodes an ERC-20 Transfer log: from and to come from the
+// indexed topics, value from the data section.
+func DecodeTransfer(lg types.Log) (from, to common.Address, value *big.Int, err error) {
+	if len(lg.Topics) != 3 || lg.Topics[0] != transferTopic {
+		return common.Address{}, common.Address{}, nil, ErrNotTransfer
+	}
+	from = common.BytesToAddress(lg.Topics[1].Bytes())
+	to = common.BytesToAddress(lg.Topics[2].Bytes())
+
+	vals, err := ParsedABI().Unpack("Transfer", lg.Data)
+	if err != nil {
+		return common.Address{}, common.Address{}, nil, fmt.Errorf("unpack Transfer data: %w", err)
+	}
+	value, ok := vals[0].(*big.Int)
+	if !ok {
+		return common.Address{}, common.Address{}, nil, fmt.Errorf("unexpected Transfer value type %T", vals[0])
+	}
+	return from, to, value, nil
+}
@@ -0,0 +1,57 @@
+package erc20
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func makeTransferLog(from, to common.Address, value *big.Int) types.Log {
+	return types.Log{
+		Topics: []common.Hash{
+			TransferTopic(),
+			common.BytesToHash(from.Bytes()),
+			common.BytesToHash(to.Bytes()),
+		},
+		Data: common.LeftPadBytes(value.Bytes(), 32),
+	}
+}
+
+func TestDecodeTransfer(t *testing.T) {
+	wantFrom := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	wantTo := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	wantValue := big.NewInt(1_500_000)
+
+	from, to, value, err := DecodeTransfer(makeTransferLog(wantFrom, wantTo, wantValue))
+	if err != nil {
+		t.Fatalf("DecodeTransfer: %v", err)
+	}
+	if from != wantFrom {
+		t.Errorf("from = %s, want %s", from, wantFrom)
+	}
+	if to != wantTo {
+		t.Errorf("to = %s, want %s", to, wantTo)
+	}
+	if value.Cmp(wantValue) != 0 {
+		t.Errorf("value = %s, want %s", value, wantValue)
+	}
+}
+
+func TestDecodeTransferRejectsOtherLogs(t *testing.T) {
+	lg := types.Log{Topics: []common.Hash{ApprovalTopic()}}
+	if _, _, _, err := DecodeTransfer(lg); !errors.Is(err, ErrNotTransfer) {
+		t.Fatalf("got %v, want ErrNotTransfer", err)
+	}
+}
+
+func TestParsedABIHasEvents(t *testing.T) {
+	a := ParsedABI()
+	for _, name := range []string{"Transfer", "Approval"} {
+		if _, ok := a.Events[name]; !ok {
+			t.Errorf("ABI missing event %s", name)
+		}
+	}
+}
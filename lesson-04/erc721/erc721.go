@@ -0,0 +1,51 @@
+// Package erc721 holds the ERC-721 Transfer event ABI for the lesson-04
+// examples. The event signature matches ERC-20's, but all three arguments
+// are indexed, so tokenId lives in Topics[3] instead of the data section.
+package erc721
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ABIJSON is the Transfer event of the ERC-721 ABI.
+const ABIJSON = `[
+	{"anonymous":false,"inputs":[
+		{"indexed":true,"name":"from","type":"address"},
+		{"indexed":true,"name":"to","type":"address"},
+		{"indexed":true,"name":"tokenId","type":"uint256"}],
+	 "name":"Transfer","type":"event"}
+]`
+
+// transferTopic equals the ERC-20 Transfer topic; only the topic count tells
+// the two standards apart.
+var transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// ErrNotTransfer is returned by DecodeTransfer for logs that are not ERC-721
+// Transfer events.
+var ErrNotTransfer = errors.New("log is not an ERC-721 Transfer event")
+
+// TransferTopic returns the topic hash of Transfer(address,address,uint256).
+func TransferTopic() common.Hash { return transferTopic }
+
+// IsTransfer reports whether lg is an ERC-721 Transfer: right signature and
+// four topics (all arguments indexed).
+func IsTransfer(lg types.Log) bool {
+	return len(lg.Topics) == 4 && lg.Topics[0] == transferTopic
+}
+
+// DecodeTransfer decodes an ERC-721 Transfer log; from, to and tokenId all
+// come from the indexed topics.
+func DecodeTransfer(lg types.Log) (from, to common.Address, tokenID *big.Int, err error) {
+	if !IsTransfer(lg) {
+		return common.Address{}, common.Address{}, nil, ErrNotTransfer
+	}
+	from = common.BytesToAddress(lg.Topics[1].Bytes())
+	to = common.BytesToAddress(lg.Topics[2].Bytes())
+	tokenID = new(big.Int).SetBytes(lg.Topics[3].Bytes())
+	return from, to, tokenID, nil
+}
@@ -0,0 +1,55 @@
+package erc721
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func nftTransferLog(from, to common.Address, tokenID *big.Int) types.Log {
+	return types.Log{
+		Topics: []common.Hash{
+			TransferTopic(),
+			common.BytesToHash(from.Bytes()),
+			common.BytesToHash(to.Bytes()),
+			common.BigToHash(tokenID),
+		},
+	}
+}
+
+func TestDecodeTransfer(t *testing.T) {
+	from := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	to := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	tokenID := big.NewInt(7001)
+
+	gotFrom, gotTo, gotID, err := DecodeTransfer(nftTransferLog(from, to, tokenID))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if gotFrom != from || gotTo != to {
+		t.Fatalf("from/to = %s/%s, want %s/%s", gotFrom, gotTo, from, to)
+	}
+	if gotID.Cmp(tokenID) != 0 {
+		t.Fatalf("tokenId = %s, want %s", gotID, tokenID)
+	}
+}
+
+func TestDecodeTransferRejectsERC20Shape(t *testing.T) {
+	// An ERC-20 Transfer has only three topics; value sits in Data.
+	lg := types.Log{
+		Topics: []common.Hash{
+			TransferTopic(),
+			common.BytesToHash(common.HexToAddress("0x1").Bytes()),
+			common.BytesToHash(common.HexToAddress("0x2").Bytes()),
+		},
+		Data: common.BigToHash(big.NewInt(100)).Bytes(),
+	}
+	if IsTransfer(lg) {
+		t.Fatal("3-topic log classified as ERC-721 Transfer")
+	}
+	if _, _, _, err := DecodeTransfer(lg); err != ErrNotTransfer {
+		t.Fatalf("err = %v, want ErrNotTransfer", err)
+	}
+}
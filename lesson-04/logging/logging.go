@@ -0,0 +1,48 @@
+// Package logging builds the shared structured logger for the lesson-04
+// binaries, configured through the environment:
+//
+//	LOG_LEVEL  debug | info | warn | error   (default info)
+//	LOG_FORMAT text | json                   (default text)
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// parseLevel maps a LOG_LEVEL value onto a slog level, defaulting to info.
+func parseLevel(s string) slog.Level {
+	switch strings.ToLower(s) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// NewLogger builds a logger writing to w, with level and handler type taken
+// from the environment.
+func NewLogger(w io.Writer) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: parseLevel(os.Getenv("LOG_LEVEL"))}
+	var handler slog.Handler
+	if strings.EqualFold(os.Getenv("LOG_FORMAT"), "json") {
+		handler = slog.NewJSONHandler(w, opts)
+	} else {
+		handler = slog.NewTextHandler(w, opts)
+	}
+	return slog.New(handler)
+}
+
+// Setup builds the stderr logger and installs it as slog's default, so both
+// the returned logger and the package-level slog functions are configured.
+func Setup() *slog.Logger {
+	logger := NewLogger(os.Stderr)
+	slog.SetDefault(logger)
+	return logger
+}
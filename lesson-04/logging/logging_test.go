@@ -0,0 +1,53 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDebugSuppressedAtInfoLevel(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "info")
+	t.Setenv("LOG_FORMAT", "text")
+
+	var buf bytes.Buffer
+	logger := NewLogger(&buf)
+	logger.Debug("noise", "block", 1)
+	logger.Info("signal", "block", 2)
+
+	out := buf.String()
+	if strings.Contains(out, "noise") {
+		t.Fatalf("debug message leaked at info level:\n%s", out)
+	}
+	if !strings.Contains(out, "signal") || !strings.Contains(out, "block=2") {
+		t.Fatalf("info message missing or unstructured:\n%s", out)
+	}
+}
+
+func TestDebugLevelEnablesDebug(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "debug")
+	t.Setenv("LOG_FORMAT", "text")
+
+	var buf bytes.Buffer
+	NewLogger(&buf).Debug("verbose")
+	if !strings.Contains(buf.String(), "verbose") {
+		t.Fatalf("debug message not emitted at debug level:\n%s", buf.String())
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "info")
+	t.Setenv("LOG_FORMAT", "json")
+
+	var buf bytes.Buffer
+	NewLogger(&buf).Info("hello", "tx", "0xabc")
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+	if entry["level"] != "INFO" || entry["msg"] != "hello" || entry["tx"] != "0xabc" {
+		t.Fatalf("entry = %v", entry)
+	}
+}
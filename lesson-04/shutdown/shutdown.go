@@ -0,0 +1,17 @@
+// Package shutdown centralizes the SIGINT/SIGTERM handling that every
+// lesson-04 binary needs, so each main no longer wires its own signal
+// channel.
+package shutdown
+
+import (
+	"context"
+	"os/signal"
+	"syscall"
+)
+
+// WaitForShutdown returns a context that is cancelled on the first SIGINT or
+// SIGTERM (or when parent is cancelled). The returned stop function releases
+// the signal registration and must be deferred by the caller.
+func WaitForShutdown(parent context.Context) (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(parent, syscall.SIGINT, syscall.SIGTERM)
+}
@@ -0,0 +1,34 @@
+package shutdown
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWaitForShutdownOnSignal(t *testing.T) {
+	ctx, stop := WaitForShutdown(context.Background())
+	defer stop()
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatalf("send SIGTERM: %v", err)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("context not cancelled after SIGTERM")
+	}
+}
+
+func TestWaitForShutdownStop(t *testing.T) {
+	ctx, stop := WaitForShutdown(context.Background())
+	stop()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("context not cancelled after stop")
+	}
+}
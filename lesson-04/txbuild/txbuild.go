@@ -0,0 +1,98 @@
+// Package txbuild provides small helpers for assembling transactions from
+// chain state: nonce and gas lookups plus constructors for the two common
+// transaction types.
+package txbuild
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Backend is the slice of ethclient.Client the helpers need. Keeping it an
+// interface lets tests use a fixed-value fake.
+type Backend interface {
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+}
+
+// SuggestGasPrice returns the node's suggested legacy gas price.
+func SuggestGasPrice(ctx context.Context, client Backend) (*big.Int, error) {
+	price, err := client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("suggest gas price: %w", err)
+	}
+	return price, nil
+}
+
+// PendingNonce returns the next nonce for addr including pending txs.
+func PendingNonce(ctx context.Context, client Backend, addr common.Address) (uint64, error) {
+	nonce, err := client.PendingNonceAt(ctx, addr)
+	if err != nil {
+		return 0, fmt.Errorf("pending nonce for %s: %w", addr, err)
+	}
+	return nonce, nil
+}
+
+// EstimateGas returns the gas estimate for executing msg.
+func EstimateGas(ctx context.Context, client Backend, msg ethereum.CallMsg) (uint64, error) {
+	gas, err := client.EstimateGas(ctx, msg)
+	if err != nil {
+		return 0, fmt.Errorf("estimate gas: %w", err)
+	}
+	return gas, nil
+}
+
+// LegacyParams holds the values needed for a pre-EIP-1559 transaction.
+type LegacyParams struct {
+	Nonce    uint64
+	To       common.Address
+	Value    *big.Int
+	Gas      uint64
+	GasPrice *big.Int
+	Data     []byte
+}
+
+// BuildLegacyTx assembles an unsigned legacy transaction.
+func BuildLegacyTx(p LegacyParams) *types.Transaction {
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    p.Nonce,
+		To:       &p.To,
+		Value:    p.Value,
+		Gas:      p.Gas,
+		GasPrice: p.GasPrice,
+		Data:     p.Data,
+	})
+}
+
+// DynamicFeeParams holds the values needed for an EIP-1559 transaction.
+type DynamicFeeParams struct {
+	ChainID   *big.Int
+	Nonce     uint64
+	To        common.Address
+	Value     *big.Int
+	Gas       uint64
+	GasTipCap *big.Int
+	GasFeeCap *big.Int
+	Data      []byte
+}
+
+// BuildDynamicFeeTx assembles an unsigned EIP-1559 transaction.
+func BuildDynamicFeeTx(p DynamicFeeParams) *types.Transaction {
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   p.ChainID,
+		Nonce:     p.Nonce,
+		To:        &p.To,
+		Value:     p.Value,
+		Gas:       p.Gas,
+		GasTipCap: p.GasTipCap,
+		GasFeeCap: p.GasFeeCap,
+		Data:      p.Data,
+	})
+}
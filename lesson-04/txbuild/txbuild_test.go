@@ -0,0 +1,90 @@
+package txbuild
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// fakeBackend returns fixed values for every lookup.
+type fakeBackend struct {
+	gasPrice *big.Int
+	tipCap   *big.Int
+	nonce    uint64
+	gas      uint64
+}
+
+func (f *fakeBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error)  { return f.gasPrice, nil }
+func (f *fakeBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) { return f.tipCap, nil }
+func (f *fakeBackend) PendingNonceAt(ctx context.Context, a common.Address) (uint64, error) {
+	return f.nonce, nil
+}
+func (f *fakeBackend) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return f.gas, nil
+}
+
+func TestWrappers(t *testing.T) {
+	ctx := context.Background()
+	backend := &fakeBackend{
+		gasPrice: big.NewInt(30_000_000_000),
+		tipCap:   big.NewInt(1_000_000_000),
+		nonce:    7,
+		gas:      21000,
+	}
+
+	price, err := SuggestGasPrice(ctx, backend)
+	if err != nil || price.Cmp(backend.gasPrice) != 0 {
+		t.Fatalf("SuggestGasPrice = %v, %v", price, err)
+	}
+	nonce, err := PendingNonce(ctx, backend, common.Address{})
+	if err != nil || nonce != 7 {
+		t.Fatalf("PendingNonce = %d, %v", nonce, err)
+	}
+	gas, err := EstimateGas(ctx, backend, ethereum.CallMsg{})
+	if err != nil || gas != 21000 {
+		t.Fatalf("EstimateGas = %d, %v", gas, err)
+	}
+}
+
+func TestBuildLegacyTx(t *testing.T) {
+	to := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	tx := BuildLegacyTx(LegacyParams{
+		Nonce:    7,
+		To:       to,
+		Value:    big.NewInt(1000),
+		Gas:      21000,
+		GasPrice: big.NewInt(30_000_000_000),
+	})
+	if tx.Type() != types.LegacyTxType {
+		t.Fatalf("type = %d, want legacy", tx.Type())
+	}
+	if tx.Nonce() != 7 || *tx.To() != to || tx.Gas() != 21000 {
+		t.Fatalf("unexpected tx fields: nonce=%d to=%s gas=%d", tx.Nonce(), tx.To(), tx.Gas())
+	}
+	if tx.GasPrice().Cmp(big.NewInt(30_000_000_000)) != 0 {
+		t.Fatalf("gas price = %s", tx.GasPrice())
+	}
+}
+
+func TestBuildDynamicFeeTx(t *testing.T) {
+	to := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	tx := BuildDynamicFeeTx(DynamicFeeParams{
+		ChainID:   big.NewInt(1),
+		Nonce:     3,
+		To:        to,
+		Value:     big.NewInt(500),
+		Gas:       21000,
+		GasTipCap: big.NewInt(1_000_000_000),
+		GasFeeCap: big.NewInt(40_000_000_000),
+	})
+	if tx.Type() != types.DynamicFeeTxType {
+		t.Fatalf("type = %d, want dynamic fee", tx.Type())
+	}
+	if tx.GasTipCap().Cmp(big.NewInt(1_000_000_000)) != 0 || tx.GasFeeCap().Cmp(big.NewInt(40_000_000_000)) != 0 {
+		t.Fatalf("tip=%s feeCap=%s", tx.GasTipCap(), tx.GasFeeCap())
+	}
+}
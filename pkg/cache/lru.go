@@ -0,0 +1,105 @@
+// Package cache provides a small, generic in-memory cache shared by the
+// examples (event store, header cache, config).
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// entry is one cached value with its key (for eviction) and optional expiry.
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time // zero means no TTL
+}
+
+// LRUCache is a thread-safe, fixed-capacity cache evicting the
+// least-recently-used entry when full. Entries may carry an individual TTL;
+// expired entries behave as absent and are dropped on access.
+type LRUCache[K comparable, V any] struct {
+	mu      sync.Mutex
+	maxSize int
+	order   *list.List // most recently used at the front
+	items   map[K]*list.Element
+
+	now func() time.Time // stubbed in tests
+}
+
+// NewLRUCache returns a cache holding at most maxSize entries.
+func NewLRUCache[K comparable, V any](maxSize int) *LRUCache[K, V] {
+	return &LRUCache[K, V]{
+		maxSize: maxSize,
+		order:   list.New(),
+		items:   make(map[K]*list.Element),
+		now:     time.Now,
+	}
+}
+
+// Get returns the value for key and marks it recently used. Missing or
+// expired keys report false.
+func (c *LRUCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	ent := elem.Value.(*entry[K, V])
+	if !ent.expiresAt.IsZero() && c.now().After(ent.expiresAt) {
+		c.remove(elem)
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(elem)
+	return ent.value, true
+}
+
+// Set stores value under key without a TTL.
+func (c *LRUCache[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, 0)
+}
+
+// SetWithTTL stores value under key, expiring it after ttl (0 disables the
+// TTL). Storing an existing key refreshes its value, TTL and recency.
+func (c *LRUCache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.now().Add(ttl)
+	}
+
+	if elem, ok := c.items[key]; ok {
+		ent := elem.Value.(*entry[K, V])
+		ent.value = value
+		ent.expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	if c.order.Len() >= c.maxSize {
+		if oldest := c.order.Back(); oldest != nil {
+			c.remove(oldest)
+		}
+	}
+	c.items[key] = c.order.PushFront(&entry[K, V]{key: key, value: value, expiresAt: expiresAt})
+}
+
+// Len reports how many entries the cache currently holds, including entries
+// whose TTL has passed but which no access has dropped yet.
+func (c *LRUCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// remove drops elem from both the list and the index; callers hold the lock.
+func (c *LRUCache[K, V]) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.items, elem.Value.(*entry[K, V]).key)
+}
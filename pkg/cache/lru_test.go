@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLRUEvictionOrder(t *testing.T) {
+	c := NewLRUCache[string, int](2)
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	// Touch "a" so "b" becomes the least recently used.
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("a should be cached")
+	}
+	c.Set("c", 3) // evicts "b"
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("b should have been evicted as LRU")
+	}
+	for key, want := range map[string]int{"a": 1, "c": 3} {
+		got, ok := c.Get(key)
+		if !ok || got != want {
+			t.Fatalf("Get(%q) = %d, %v; want %d, true", key, got, ok, want)
+		}
+	}
+	if c.Len() != 2 {
+		t.Fatalf("Len = %d, want 2", c.Len())
+	}
+}
+
+func TestLRUUpdateRefreshesRecency(t *testing.T) {
+	c := NewLRUCache[string, int](2)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("a", 10) // refresh: "b" is now LRU
+	c.Set("c", 3)
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("b should have been evicted")
+	}
+	if got, _ := c.Get("a"); got != 10 {
+		t.Fatalf("a = %d, want updated 10", got)
+	}
+}
+
+func TestLRUTTLExpiry(t *testing.T) {
+	c := NewLRUCache[string, int](4)
+	clock := time.Now()
+	c.now = func() time.Time { return clock }
+
+	c.SetWithTTL("short", 1, time.Minute)
+	c.Set("forever", 2)
+
+	clock = clock.Add(2 * time.Minute)
+
+	if _, ok := c.Get("short"); ok {
+		t.Fatal("short should have expired")
+	}
+	if _, ok := c.Get("forever"); !ok {
+		t.Fatal("entry without TTL must not expire")
+	}
+	if c.Len() != 1 {
+		t.Fatalf("Len = %d, want 1 after the expired entry was dropped", c.Len())
+	}
+}
+
+func TestLRUConcurrentAccess(t *testing.T) {
+	c := NewLRUCache[int, string](64)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				key := (g*200 + i) % 100
+				c.Set(key, fmt.Sprintf("v%d", key))
+				if v, ok := c.Get(key); ok && v != fmt.Sprintf("v%d", key) {
+					t.Errorf("Get(%d) = %q", key, v)
+				}
+				c.Len()
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if c.Len() > 64 {
+		t.Fatalf("Len = %d, exceeds max size", c.Len())
+	}
+}
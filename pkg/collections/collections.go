@@ -0,0 +1,71 @@
+// Package collections provides small generic containers used by the lesson
+// examples, such as the stacks behind the shunting-yard evaluator.
+package collections
+
+// Stack is a LIFO container.
+type Stack[T any] struct {
+	items []T
+}
+
+// Push adds v on top of the stack.
+func (s *Stack[T]) Push(v T) {
+	s.items = append(s.items, v)
+}
+
+// Pop removes and returns the top element; ok is false when the stack is
+// empty.
+func (s *Stack[T]) Pop() (v T, ok bool) {
+	if len(s.items) == 0 {
+		return v, false
+	}
+	v = s.items[len(s.items)-1]
+	s.items = s.items[:len(s.items)-1]
+	return v, true
+}
+
+// Peek returns the top element without removing it; ok is false when empty.
+func (s *Stack[T]) Peek() (v T, ok bool) {
+	if len(s.items) == 0 {
+		return v, false
+	}
+	return s.items[len(s.items)-1], true
+}
+
+// Len returns the number of stacked elements.
+func (s *Stack[T]) Len() int {
+	return len(s.items)
+}
+
+// Queue is a FIFO container.
+type Queue[T any] struct {
+	items []T
+}
+
+// Push appends v to the back of the queue.
+func (q *Queue[T]) Push(v T) {
+	q.items = append(q.items, v)
+}
+
+// Pop removes and returns the front element; ok is false when the queue is
+// empty.
+func (q *Queue[T]) Pop() (v T, ok bool) {
+	if len(q.items) == 0 {
+		return v, false
+	}
+	v = q.items[0]
+	q.items = q.items[1:]
+	return v, true
+}
+
+// Peek returns the front element without removing it; ok is false when empty.
+func (q *Queue[T]) Peek() (v T, ok bool) {
+	if len(q.items) == 0 {
+		return v, false
+	}
+	return q.items[0], true
+}
+
+// Len returns the number of queued elements.
+func (q *Queue[T]) Len() int {
+	return len(q.items)
+}
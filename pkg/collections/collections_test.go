@@ -0,0 +1,73 @@
+package collections
+
+import "testing"
+
+func TestStack(t *testing.T) {
+	var s Stack[int]
+
+	if _, ok := s.Pop(); ok {
+		t.Fatal("Pop on empty stack returned ok")
+	}
+	if _, ok := s.Peek(); ok {
+		t.Fatal("Peek on empty stack returned ok")
+	}
+
+	s.Push(1)
+	s.Push(2)
+	s.Push(3)
+	if s.Len() != 3 {
+		t.Fatalf("Len = %d, want 3", s.Len())
+	}
+
+	if v, ok := s.Peek(); !ok || v != 3 {
+		t.Fatalf("Peek = %v, %v", v, ok)
+	}
+	if s.Len() != 3 {
+		t.Fatal("Peek removed an element")
+	}
+
+	for want := 3; want >= 1; want-- {
+		v, ok := s.Pop()
+		if !ok || v != want {
+			t.Fatalf("Pop = %v, %v; want %d", v, ok, want)
+		}
+	}
+	if _, ok := s.Pop(); ok {
+		t.Fatal("Pop on drained stack returned ok")
+	}
+}
+
+func TestQueue(t *testing.T) {
+	var q Queue[string]
+
+	if _, ok := q.Pop(); ok {
+		t.Fatal("Pop on empty queue returned ok")
+	}
+	if _, ok := q.Peek(); ok {
+		t.Fatal("Peek on empty queue returned ok")
+	}
+
+	q.Push("a")
+	q.Push("b")
+	q.Push("c")
+	if q.Len() != 3 {
+		t.Fatalf("Len = %d, want 3", q.Len())
+	}
+
+	if v, ok := q.Peek(); !ok || v != "a" {
+		t.Fatalf("Peek = %v, %v", v, ok)
+	}
+	if q.Len() != 3 {
+		t.Fatal("Peek removed an element")
+	}
+
+	for _, want := range []string{"a", "b", "c"} {
+		v, ok := q.Pop()
+		if !ok || v != want {
+			t.Fatalf("Pop = %v, %v; want %q", v, ok, want)
+		}
+	}
+	if _, ok := q.Pop(); ok {
+		t.Fatal("Pop on drained queue returned ok")
+	}
+}
@@ -0,0 +1,35 @@
+package collections
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// MapConcurrent applies fn to every item with at most concurrency goroutines
+// in flight, preserving input order in the result slice. The first error
+// cancels the context handed to the remaining calls and is returned;
+// concurrency values below 1 mean unbounded.
+func MapConcurrent[T, U any](ctx context.Context, items []T, concurrency int, fn func(context.Context, T) (U, error)) ([]U, error) {
+	results := make([]U, len(items))
+
+	g, ctx := errgroup.WithContext(ctx)
+	if concurrency > 0 {
+		g.SetLimit(concurrency)
+	}
+	for i, item := range items {
+		i, item := i, item
+		g.Go(func() error {
+			out, err := fn(ctx, item)
+			if err != nil {
+				return err
+			}
+			results[i] = out
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,74 @@
+package collections
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMapConcurrentPreservesOrder(t *testing.T) {
+	items := make([]int, 50)
+	for i := range items {
+		items[i] = i
+	}
+
+	got, err := MapConcurrent(context.Background(), items, 8, func(_ context.Context, n int) (string, error) {
+		// Stagger completion so out-of-order finishes would show up.
+		time.Sleep(time.Duration(50-n) * 100 * time.Microsecond)
+		return fmt.Sprintf("#%d", n), nil
+	})
+	if err != nil {
+		t.Fatalf("MapConcurrent: %v", err)
+	}
+	for i, s := range got {
+		if want := fmt.Sprintf("#%d", i); s != want {
+			t.Fatalf("got[%d] = %q, want %q", i, s, want)
+		}
+	}
+}
+
+func TestMapConcurrentRespectsLimit(t *testing.T) {
+	const limit = 3
+	var inFlight, maxInFlight atomic.Int64
+
+	_, err := MapConcurrent(context.Background(), make([]int, 40), limit, func(context.Context, int) (int, error) {
+		cur := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			max := maxInFlight.Load()
+			if cur <= max || maxInFlight.CompareAndSwap(max, cur) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		return 0, nil
+	})
+	if err != nil {
+		t.Fatalf("MapConcurrent: %v", err)
+	}
+	if got := maxInFlight.Load(); got > limit {
+		t.Fatalf("max in-flight = %d, want <= %d", got, limit)
+	}
+}
+
+func TestMapConcurrentFirstErrorCancelsRest(t *testing.T) {
+	boom := errors.New("item 5 broke")
+
+	_, err := MapConcurrent(context.Background(), []int{0, 1, 2, 3, 4, 5, 6, 7}, 2, func(ctx context.Context, n int) (int, error) {
+		if n == 5 {
+			return 0, boom
+		}
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(20 * time.Millisecond):
+			return n, nil
+		}
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want the first real error", err)
+	}
+}
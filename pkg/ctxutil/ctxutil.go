@@ -0,0 +1,47 @@
+// Package ctxutil centralizes the context keys used across the lessons so
+// examples stop declaring their own ad-hoc contextKey types. Keys are
+// unexported struct types, which cannot collide with keys from other
+// packages.
+package ctxutil
+
+import "context"
+
+type (
+	requestIDKey struct{}
+	userIDKey    struct{}
+	operatorKey  struct{}
+)
+
+// WithRequestID returns a context carrying the request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID and whether one was set.
+func RequestID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+// WithUserID returns a context carrying the authenticated user ID.
+func WithUserID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, userIDKey{}, id)
+}
+
+// UserID returns the user ID and whether one was set.
+func UserID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(userIDKey{}).(string)
+	return id, ok
+}
+
+// WithOperator returns a context carrying the name of the actor performing a
+// change, used by the GORM audit hooks.
+func WithOperator(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, operatorKey{}, name)
+}
+
+// Operator returns the operator name and whether one was set.
+func Operator(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(operatorKey{}).(string)
+	return name, ok
+}
@@ -0,0 +1,36 @@
+package ctxutil
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	ctx = WithRequestID(ctx, "req-123")
+	ctx = WithUserID(ctx, "user-7")
+	ctx = WithOperator(ctx, "admin")
+
+	if id, ok := RequestID(ctx); !ok || id != "req-123" {
+		t.Errorf("RequestID = %q, %v", id, ok)
+	}
+	if id, ok := UserID(ctx); !ok || id != "user-7" {
+		t.Errorf("UserID = %q, %v", id, ok)
+	}
+	if name, ok := Operator(ctx); !ok || name != "admin" {
+		t.Errorf("Operator = %q, %v", name, ok)
+	}
+}
+
+func TestAbsentKeys(t *testing.T) {
+	ctx := context.Background()
+	if id, ok := RequestID(ctx); ok || id != "" {
+		t.Errorf("RequestID on empty ctx = %q, %v", id, ok)
+	}
+	if id, ok := UserID(ctx); ok || id != "" {
+		t.Errorf("UserID on empty ctx = %q, %v", id, ok)
+	}
+	if name, ok := Operator(ctx); ok || name != "" {
+		t.Errorf("Operator on empty ctx = %q, %v", name, ok)
+	}
+}
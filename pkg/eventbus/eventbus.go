@@ -0,0 +1,70 @@
+// Package eventbus provides a minimal in-process publish/subscribe bus used
+// to decouple event producers from their consumers.
+package eventbus
+
+import "sync"
+
+// Bus fans values out to every subscriber. Publishing never blocks: each
+// subscriber gets a buffered channel, and a subscriber that stops draining
+// loses events once its buffer is full rather than stalling the producer.
+type Bus[T any] struct {
+	mu     sync.Mutex
+	buffer int
+	subs   map[chan T]struct{}
+}
+
+// New returns a bus whose subscriber channels buffer the given number of
+// events (minimum 1).
+func New[T any](buffer int) *Bus[T] {
+	if buffer < 1 {
+		buffer = 1
+	}
+	return &Bus[T]{buffer: buffer, subs: make(map[chan T]struct{})}
+}
+
+// Subscribe registers a new subscriber and returns its channel. Pass it to
+// Unsubscribe when done.
+func (b *Bus[T]) Subscribe() <-chan T {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ch := make(chan T, b.buffer)
+	b.subs[ch] = struct{}{}
+	return ch
+}
+
+// Unsubscribe removes the subscriber and closes its channel, so a consumer
+// ranging over it terminates.
+func (b *Bus[T]) Unsubscribe(ch <-chan T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subs {
+		if (<-chan T)(sub) == ch {
+			delete(b.subs, sub)
+			close(sub)
+			return
+		}
+	}
+}
+
+// Publish delivers v to every subscriber that has buffer space and reports
+// how many received it.
+func (b *Bus[T]) Publish(v T) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delivered := 0
+	for sub := range b.subs {
+		select {
+		case sub <- v:
+			delivered++
+		default: // subscriber is full; drop rather than block the producer
+		}
+	}
+	return delivered
+}
+
+// Subscribers reports how many subscribers are registered.
+func (b *Bus[T]) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs)
+}
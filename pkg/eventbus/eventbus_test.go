@@ -0,0 +1,74 @@
+package eventbus
+
+import (
+	"testing"
+	"time"
+)
+
+func recv(t *testing.T, ch <-chan string) string {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for an event")
+		return ""
+	}
+}
+
+func TestPublishFansOut(t *testing.T) {
+	bus := New[string](4)
+	a := bus.Subscribe()
+	b := bus.Subscribe()
+
+	if n := bus.Publish("hello"); n != 2 {
+		t.Fatalf("delivered to %d subscribers, want 2", n)
+	}
+	if got := recv(t, a); got != "hello" {
+		t.Fatalf("a received %q", got)
+	}
+	if got := recv(t, b); got != "hello" {
+		t.Fatalf("b received %q", got)
+	}
+}
+
+func TestUnsubscribeClosesChannel(t *testing.T) {
+	bus := New[string](4)
+	a := bus.Subscribe()
+	b := bus.Subscribe()
+
+	bus.Unsubscribe(a)
+	if _, open := <-a; open {
+		t.Fatal("unsubscribed channel should be closed")
+	}
+	if bus.Subscribers() != 1 {
+		t.Fatalf("subscribers = %d, want 1", bus.Subscribers())
+	}
+
+	if n := bus.Publish("still running"); n != 1 {
+		t.Fatalf("delivered to %d subscribers, want 1", n)
+	}
+	if got := recv(t, b); got != "still running" {
+		t.Fatalf("b received %q", got)
+	}
+}
+
+func TestPublishNeverBlocksOnFullSubscriber(t *testing.T) {
+	bus := New[string](1)
+	slow := bus.Subscribe()
+
+	bus.Publish("first") // fills slow's buffer
+	done := make(chan struct{})
+	go func() {
+		bus.Publish("second") // must not block even though slow is full
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked on a full subscriber")
+	}
+	if got := recv(t, slow); got != "first" {
+		t.Fatalf("slow received %q, want the buffered first event", got)
+	}
+}
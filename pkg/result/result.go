@@ -0,0 +1,70 @@
+// Package result offers small Result and Option types to illustrate
+// functional-style error handling next to Go's usual (value, error) pairs.
+// It is a teaching aid, not a framework; plain errors remain the default in
+// the lessons.
+package result
+
+import "fmt"
+
+// Result holds either a value or an error.
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok wraps a successful value.
+func Ok[T any](value T) Result[T] {
+	return Result[T]{value: value}
+}
+
+// Err wraps a failure.
+func Err[T any](err error) Result[T] {
+	return Result[T]{err: err}
+}
+
+// IsOk reports whether the result holds a value.
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// Unwrap returns the value, panicking if the result is an error.
+func (r Result[T]) Unwrap() T {
+	if r.err != nil {
+		panic(fmt.Sprintf("unwrap of error result: %v", r.err))
+	}
+	return r.value
+}
+
+// UnwrapOr returns the value, or fallback if the result is an error.
+func (r Result[T]) UnwrapOr(fallback T) T {
+	if r.err != nil {
+		return fallback
+	}
+	return r.value
+}
+
+// Err returns the held error, or nil.
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+// Option holds a value or nothing.
+type Option[T any] struct {
+	value T
+	ok    bool
+}
+
+// Some wraps a present value.
+func Some[T any](value T) Option[T] {
+	return Option[T]{value: value, ok: true}
+}
+
+// None returns the absent option.
+func None[T any]() Option[T] {
+	return Option[T]{}
+}
+
+// Get returns the value and whether it is present.
+func (o Option[T]) Get() (T, bool) {
+	return o.value, o.ok
+}
@@ -0,0 +1,55 @@
+package result
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResultOk(t *testing.T) {
+	r := Ok(42)
+	if !r.IsOk() {
+		t.Fatal("Ok result reports !IsOk")
+	}
+	if r.Err() != nil {
+		t.Fatalf("Err = %v, want nil", r.Err())
+	}
+	if got := r.Unwrap(); got != 42 {
+		t.Fatalf("Unwrap = %d, want 42", got)
+	}
+	if got := r.UnwrapOr(7); got != 42 {
+		t.Fatalf("UnwrapOr = %d, want 42", got)
+	}
+}
+
+func TestResultErr(t *testing.T) {
+	boom := errors.New("boom")
+	r := Err[string](boom)
+	if r.IsOk() {
+		t.Fatal("Err result reports IsOk")
+	}
+	if !errors.Is(r.Err(), boom) {
+		t.Fatalf("Err = %v, want boom", r.Err())
+	}
+	if got := r.UnwrapOr("fallback"); got != "fallback" {
+		t.Fatalf("UnwrapOr = %q, want fallback", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Unwrap on error result did not panic")
+		}
+	}()
+	r.Unwrap()
+}
+
+func TestOption(t *testing.T) {
+	some := Some("hello")
+	if v, ok := some.Get(); !ok || v != "hello" {
+		t.Fatalf("Some.Get = %q, %v", v, ok)
+	}
+
+	none := None[string]()
+	if v, ok := none.Get(); ok || v != "" {
+		t.Fatalf("None.Get = %q, %v; want zero, false", v, ok)
+	}
+}
@@ -0,0 +1,90 @@
+// Package retry provides a shared retry-with-backoff helper for
+// network-facing code (RPC calls, gRPC clients, the web crawler).
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Policy controls how Retry paces its attempts.
+type Policy struct {
+	MaxAttempts int           // total attempts, including the first
+	BaseDelay   time.Duration // delay after the first failure
+	MaxDelay    time.Duration // cap for the doubling backoff
+	Jitter      bool          // randomize each delay to avoid thundering herds
+}
+
+// DefaultPolicy suits most transient-failure situations.
+var DefaultPolicy = Policy{
+	MaxAttempts: 3,
+	BaseDelay:   100 * time.Millisecond,
+	MaxDelay:    2 * time.Second,
+	Jitter:      true,
+}
+
+// RetryableError lets an error say whether retrying can help. Errors that
+// don't implement it are treated as retryable.
+type RetryableError interface {
+	error
+	Retryable() bool
+}
+
+// permanentError marks an error as not worth retrying.
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string   { return e.err.Error() }
+func (e *permanentError) Unwrap() error   { return e.err }
+func (e *permanentError) Retryable() bool { return false }
+
+// Permanent wraps err so Retry gives up on it immediately.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// delay computes the backoff before attempt (0-based counting failures),
+// doubling from BaseDelay up to MaxDelay, with optional jitter of up to 50%.
+func (p Policy) delay(attempt int) time.Duration {
+	d := p.BaseDelay << attempt
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if p.Jitter && d > 0 {
+		d += time.Duration(rand.Int63n(int64(d)/2 + 1))
+	}
+	return d
+}
+
+// Retry runs fn until it succeeds, returns a non-retryable error, the policy
+// is exhausted, or ctx is cancelled (also mid-backoff). On exhaustion the
+// last error comes back; on cancellation the context error wraps it.
+func Retry(ctx context.Context, policy Policy, fn func() error) error {
+	var lastErr error
+	for attempt := 0; attempt < policy.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(policy.delay(attempt - 1))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return fmt.Errorf("%w (last error: %v)", ctx.Err(), lastErr)
+			case <-timer.C:
+			}
+		}
+
+		lastErr = fn()
+		if lastErr == nil {
+			return nil
+		}
+		var retryable RetryableError
+		if errors.As(lastErr, &retryable) && !retryable.Retryable() {
+			return lastErr
+		}
+	}
+	return lastErr
+}
@@ -0,0 +1,91 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// fastPolicy keeps the tests quick.
+var fastPolicy = Policy{MaxAttempts: 4, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+func TestRetrySucceedsAfterFailures(t *testing.T) {
+	calls := 0
+	err := Retry(context.Background(), fastPolicy, func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("transient")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Retry: %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("fn ran %d times, want 3", calls)
+	}
+}
+
+func TestRetryExhaustionReturnsLastError(t *testing.T) {
+	calls := 0
+	err := Retry(context.Background(), fastPolicy, func() error {
+		calls++
+		return fmt.Errorf("attempt %d failed", calls)
+	})
+	if err == nil || err.Error() != "attempt 4 failed" {
+		t.Fatalf("err = %v, want the last attempt's error", err)
+	}
+	if calls != fastPolicy.MaxAttempts {
+		t.Fatalf("fn ran %d times, want %d", calls, fastPolicy.MaxAttempts)
+	}
+}
+
+func TestRetryStopsOnPermanentError(t *testing.T) {
+	boom := errors.New("bad request")
+	calls := 0
+	err := Retry(context.Background(), fastPolicy, func() error {
+		calls++
+		return fmt.Errorf("call: %w", Permanent(boom))
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want wrapped boom", err)
+	}
+	if calls != 1 {
+		t.Fatalf("fn ran %d times, want 1 (no retry on permanent errors)", calls)
+	}
+}
+
+func TestRetryCancelledMidBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	slow := Policy{MaxAttempts: 3, BaseDelay: time.Minute}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- Retry(ctx, slow, func() error { return errors.New("transient") })
+	}()
+
+	// Let the first attempt fail, then cancel during the minute-long backoff.
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("err = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Retry did not return after cancellation")
+	}
+}
+
+func TestPolicyDelayCapsAndDoubles(t *testing.T) {
+	p := Policy{BaseDelay: 10 * time.Millisecond, MaxDelay: 25 * time.Millisecond}
+	wants := []time.Duration{10, 20, 25, 25}
+	for attempt, want := range wants {
+		if got := p.delay(attempt); got != want*time.Millisecond {
+			t.Fatalf("delay(%d) = %v, want %v", attempt, got, want*time.Millisecond)
+		}
+	}
+}